@@ -0,0 +1,187 @@
+package brag
+
+import (
+	"bytes"
+	"cli/main/cmd/daily"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// BragCmd builds a brag document from months of activity and the notes of daily runs
+var BragCmd = &cobra.Command{
+	Use:   "brag",
+	Short: "Build a brag document from your activity since a date",
+	Long: `Fetch your GitHub contributions and the Linear issues you completed since
+--since, join them with the notes of the days recorded by "daily", and write a
+markdown brag document: counts, completed issues grouped by project and theme
+(their first label), the largest merged pull requests with their diff sizes,
+and the entries you wrote along the way.
+
+GitHub is fetched a month at a time, so the period can be as long as you like.
+The document is rendered with a built-in template, or the one at "brag.template".
+An existing document is left untouched unless the new one renders, and --on-exists
+says whether to overwrite, append to or merge with it.
+
+Example:
+  crab brag --since 2026-04-01
+  crab brag --since 2026-01-01 --until 2026-06-30 --top 5
+  crab brag --since 2026-04-01 -o -     # Print the document
+  crab brag --since 2026-04-01 --on-exists overwrite`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := viper.GetViper()
+		console := cmd.ErrOrStderr()
+		now := time.Now()
+
+		sinceFlag, _ := cmd.Flags().GetString("since")
+		since, err := daily.ParseDay(sinceFlag, now)
+		if err != nil {
+			return err
+		}
+		until := now
+		if untilFlag, _ := cmd.Flags().GetString("until"); untilFlag != "" {
+			until, err = daily.ParseDay(untilFlag, now)
+			if err != nil {
+				return err
+			}
+		}
+		if until.Before(since) {
+			return fmt.Errorf("--until %s is before --since %s", daily.DayKey(until), daily.DayKey(since))
+		}
+		top, _ := cmd.Flags().GetInt("top")
+		onExistsFlag, _ := cmd.Flags().GetString("on-exists")
+		if !cmd.Flags().Changed("on-exists") && config.IsSet("output.onExists") {
+			onExistsFlag = config.GetString("output.onExists")
+		}
+		onExists, err := daily.ParseExistingAction(onExistsFlag)
+		if err != nil {
+			return err
+		}
+
+		dataDir, err := daily.DataDir(config)
+		if err != nil {
+			return err
+		}
+		days, err := daily.StoredDays(dataDir, since, until)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		window := daily.ReportWindow(since, until, now)
+		fmt.Fprintf(console, "🔍 Fetching activity from %s to %s\n", daily.DayKey(since), daily.DayKey(until))
+		contributions, issues, unavailable := fetch(ctx, daily.NewHTTPClient(config), window, config)
+		fmt.Fprintf(console, "📚 %d recorded day(s) between %s and %s\n", len(days), daily.DayKey(since), daily.DayKey(until))
+
+		data := daily.BuildBrag(since, until, contributions, issues, days, top)
+		data.Unavailable = unavailable
+
+		output, _ := cmd.Flags().GetString("output")
+		path, err := daily.ResolveBragPath(output, config.GetString("output.dir"), since, until)
+		if err != nil {
+			return err
+		}
+
+		// Render first so a failing template leaves an existing document as it was
+		var rendered bytes.Buffer
+		if err := daily.RenderBrag(&rendered, data, config.GetString("brag.template")); err != nil {
+			return err
+		}
+
+		if _, statErr := os.Stat(path); path != daily.StdoutPath && statErr == nil && onExists == daily.ExistingAsk {
+			if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+				return fmt.Errorf("%s already exists, use --on-exists to overwrite, append or merge", path)
+			}
+			onExists, err = daily.PromptExistingAction(os.Stdin, path)
+			if err != nil {
+				return err
+			}
+			if onExists == daily.ExistingCancel {
+				fmt.Fprintln(console, "🚫 Cancelled, the existing brag document was left untouched")
+				return nil
+			}
+		}
+
+		if err := daily.WriteRenderedOutput(rendered.Bytes(), daily.FormatMarkdown, path, onExists); err != nil {
+			return err
+		}
+		if path != daily.StdoutPath {
+			fmt.Fprintf(console, "📂 File: %s\n", path)
+		}
+
+		if len(unavailable) > 0 {
+			return &daily.PartialRunError{Unavailable: unavailable}
+		}
+		return nil
+	},
+}
+
+// fetch gets the GitHub contributions and completed Linear issues of the window at the
+// same time, from whichever of the two is enabled. Failures are returned as unavailable.
+func fetch(ctx context.Context, client *http.Client, window daily.Window, config *viper.Viper) (*daily.GitHubContributions, []daily.LinearCompletedIssue, []daily.SourceStatus) {
+	enabled, _ := daily.EnabledSources(config)
+
+	var (
+		wg            sync.WaitGroup
+		mu            sync.Mutex
+		contributions *daily.GitHubContributions
+		issues        []daily.LinearCompletedIssue
+		unavailable   []daily.SourceStatus
+	)
+	fail := func(source string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		unavailable = append(unavailable, daily.SourceStatus{Source: source, Reason: err.Error()})
+	}
+
+	for _, source := range enabled {
+		switch source.Name() {
+		case "github":
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fetched, err := daily.GetViewerContributions(ctx, client, window.Since, window.Until, config)
+				if err != nil {
+					fail("github", err)
+					return
+				}
+				contributions = &fetched
+			}()
+		case "linear":
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				fetched, err := daily.GetCompletedIssues(ctx, client, window.Since, window.Until, config)
+				if err != nil {
+					fail("linear", err)
+					return
+				}
+				issues = fetched
+			}()
+		}
+	}
+	wg.Wait()
+
+	return contributions, issues, unavailable
+}
+
+func init() {
+	BragCmd.Flags().String("since", "", "First day of the brag document: YYYY-MM-DD, today or yesterday")
+	BragCmd.Flags().String("until", "", "Last day of the brag document (default: today)")
+	BragCmd.Flags().Int("top", daily.DefaultNotablePullRequests, "How many of the largest merged pull requests to list")
+	BragCmd.Flags().StringP("output", "o", "", "Brag document file or directory, or - for stdout (default: output.dir or the current directory)")
+	BragCmd.Flags().String("on-exists", daily.ExistingAsk, "When the brag document exists: ask, overwrite, append, merge or cancel")
+	BragCmd.MarkFlagRequired("since")
+}
@@ -0,0 +1,90 @@
+package daily
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Answer is a prepared answer for a Linear issue, given with --answers
+type Answer struct {
+	Worked bool   `yaml:"worked"`
+	Notes  string `yaml:"notes"`
+	// Time is the time spent as typed, e.g. 1h30 or 45m. LoadAnswers reads it into TimeSpent.
+	Time      string        `yaml:"time"`
+	TimeSpent time.Duration `yaml:"-"`
+}
+
+// UnmarshalYAML accepts either the notes alone, meaning the issue was worked on,
+// or a mapping with "worked", "notes" and "time"
+func (a *Answer) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		a.Worked = true
+		return node.Decode(&a.Notes)
+	}
+
+	type plain Answer
+	answer := plain{Worked: true}
+	if err := node.Decode(&answer); err != nil {
+		return err
+	}
+	*a = Answer(answer)
+	return nil
+}
+
+// Answers maps Linear identifiers (e.g. "ENG-123") to prepared answers
+type Answers map[string]Answer
+
+// LoadAnswers reads an answers file. It is YAML (or JSON) keyed by Linear identifier:
+//
+//	ENG-123: Fixed the retry loop
+//	ENG-124:
+//	  worked: false
+//	ENG-125:
+//	  notes: Paired on the migration
+//	  time: 1h30
+func LoadAnswers(path string) (Answers, error) {
+	path, err := expandHome(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve answers file: %w", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file %s: %w", path, err)
+	}
+
+	answers := make(Answers, len(raw))
+	for identifier, node := range raw {
+		// An identifier without notes was worked on
+		answer := Answer{Worked: true}
+		if node.Tag != "!!null" {
+			if err := node.Decode(&answer); err != nil {
+				return nil, fmt.Errorf("failed to parse the answer for %s in %s: %w", identifier, path, err)
+			}
+		}
+		answer.Notes = strings.TrimSpace(answer.Notes)
+		if answer.Time != "" {
+			answer.TimeSpent, err = ParseTimeSpent(answer.Time)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse the time spent on %s in %s: %w", identifier, path, err)
+			}
+		}
+		answers[strings.ToUpper(strings.TrimSpace(identifier))] = answer
+	}
+	return answers, nil
+}
+
+// Lookup returns the answer prepared for an issue identifier, ignoring case
+func (a Answers) Lookup(identifier string) (Answer, bool) {
+	answer, ok := a[strings.ToUpper(identifier)]
+	return answer, ok
+}
@@ -0,0 +1,54 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test answers can be given as plain notes or as a mapping
+func Test_LoadAnswers(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	content := "ENG-123: Fixed the retry loop\neng-124:\n  worked: false\nENG-125:\n  notes: |\n    Paired on the migration\n    Deployed it\nENG-126:\nENG-127:\n  notes: Shipped it\n  time: 1h30\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	// Act
+	answers, err := LoadAnswers(path)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, Answer{Worked: true, Notes: "Fixed the retry loop"}, answers["ENG-123"])
+	assert.Equal(t, Answer{Worked: false}, answers["ENG-124"])
+	assert.Equal(t, Answer{Worked: true, Notes: "Paired on the migration\nDeployed it"}, answers["ENG-125"])
+	assert.Equal(t, Answer{Worked: true}, answers["ENG-126"])
+	assert.Equal(t, Answer{Worked: true, Notes: "Shipped it", Time: "1h30", TimeSpent: 90 * time.Minute}, answers["ENG-127"])
+
+	answer, ok := answers.Lookup("eng-124")
+	assert.True(t, ok, "identifiers are matched ignoring case")
+	assert.False(t, answer.Worked)
+}
+
+// Test an unreadable answers file is reported
+func Test_LoadAnswers_Invalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- not a mapping\n"), 0o644))
+
+	_, err := LoadAnswers(path)
+	assert.ErrorContains(t, err, "failed to parse answers file")
+
+	require.NoError(t, os.WriteFile(path, []byte("ENG-1:\n  time: 45\n"), 0o644))
+	_, err = LoadAnswers(path)
+	assert.ErrorContains(t, err, "failed to parse the time spent on ENG-1")
+
+	_, err = LoadAnswers(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read answers file")
+
+	var answers Answers
+	_, ok := answers.Lookup("ENG-1")
+	assert.False(t, ok, "no answers file means no answers")
+}
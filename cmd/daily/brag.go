@@ -0,0 +1,246 @@
+package daily
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+//go:embed templates/brag.md.tmpl
+var defaultBragTemplate string
+
+// DefaultNotablePullRequests is how many of the largest merged pull requests a brag document lists
+const DefaultNotablePullRequests = 10
+
+// BragData is the data model handed to brag document templates.
+//
+// Templates can use .Since, .Until, .Username, the counts .IssuesCompleted,
+// .PullRequestsMerged, .PullRequestsOpened, .Reviews, .Commits, .IssuesOpened,
+// .LinesAdded and .LinesDeleted, as well as:
+//   - .Projects             []BragProject with .Name, .Issues (count) and .Themes, each with
+//     .Name and .Issues ([]BragIssue with .Identifier, .Title, .URL, .CompletedAt and .Notes)
+//   - .NotablePullRequests  []GitHubPullRequest, the largest merged ones, with .Additions,
+//     .Deletions, .ChangedFiles, .MergedAt and .Notes
+//   - .Repositories         []BragRepository with .Name, .Commits, .PullRequests and .Reviews
+//   - .Entries              []FreeFormEntry from the daily runs
+//   - .Unavailable          []SourceStatus for sources that could not be fetched
+type BragData struct {
+	Since    time.Time
+	Until    time.Time
+	Username string
+
+	IssuesCompleted    int
+	PullRequestsMerged int
+	PullRequestsOpened int
+	Reviews            int
+	Commits            int
+	IssuesOpened       int
+	LinesAdded         int
+	LinesDeleted       int
+
+	Projects            []BragProject
+	NotablePullRequests []GitHubPullRequest
+	Repositories        []BragRepository
+	Entries             []FreeFormEntry
+	Unavailable         []SourceStatus
+}
+
+// BragProject is the Linear issues completed for a project, grouped by theme
+type BragProject struct {
+	Name   string
+	Issues int
+	Themes []BragTheme
+}
+
+// BragTheme is the issues of a project sharing their first label
+type BragTheme struct {
+	Name   string
+	Issues []BragIssue
+}
+
+// BragIssue is a completed Linear issue with the notes written about it in daily runs
+type BragIssue struct {
+	Identifier  string
+	Title       string
+	URL         string
+	CompletedAt time.Time
+	Notes       string
+}
+
+// BragRepository is the contributions made to a repository
+type BragRepository struct {
+	Name         string
+	Commits      int
+	PullRequests int
+	Reviews      int
+}
+
+// Names used for issues without a project or a label
+const (
+	bragNoProject = "Other"
+	bragNoTheme   = "General"
+)
+
+// BuildBrag puts together a brag document from the contributions fetched for the period
+// and the days recorded by daily runs, which bring the notes. contributions may be nil
+// when GitHub was not fetched.
+func BuildBrag(since time.Time, until time.Time, contributions *GitHubContributions, issues []LinearCompletedIssue, days []DailyExport, notable int) BragData {
+	rollup := RollupDays(days)
+	data := BragData{Since: since, Until: until}
+
+	issueNotes := make(map[string]string)
+	for _, issue := range rollup.Issues {
+		issueNotes[issue.Identifier] = issue.Notes
+	}
+	prNotes := make(map[string]string)
+	for _, pr := range append(append([]ExportPullRequest{}, rollup.GitHub.PullRequests...), rollup.GitHub.Reviews...) {
+		prNotes[pr.URL] = MergeNotes(prNotes[pr.URL], pr.Notes)
+	}
+
+	data.Projects = bragProjects(issues, issueNotes)
+	data.IssuesCompleted = len(issues)
+
+	if contributions != nil {
+		data.Username = contributions.Username
+
+		repos := make(map[string]*BragRepository)
+		repo := func(name string) *BragRepository {
+			if repos[name] == nil {
+				repos[name] = &BragRepository{Name: name}
+			}
+			return repos[name]
+		}
+		for name, count := range contributions.CommitsByRepo {
+			repo(name).Commits += count
+			data.Commits += count
+		}
+		for name, count := range contributions.ReviewsByRepo {
+			repo(name).Reviews += count
+			data.Reviews += count
+		}
+		for _, count := range contributions.IssuesByRepo {
+			data.IssuesOpened += count
+		}
+
+		var merged []GitHubPullRequest
+		for _, pr := range contributions.PullRequests {
+			repo(pr.RepoOwner+"/"+pr.RepoName).PullRequests++
+			data.PullRequestsOpened++
+			if pr.MergedAt == "" {
+				continue
+			}
+			pr.Notes = prNotes[pr.URL]
+			merged = append(merged, pr)
+			data.PullRequestsMerged++
+			data.LinesAdded += pr.Additions
+			data.LinesDeleted += pr.Deletions
+		}
+
+		sort.SliceStable(merged, func(i, j int) bool {
+			return merged[i].Additions+merged[i].Deletions > merged[j].Additions+merged[j].Deletions
+		})
+		if len(merged) > notable {
+			merged = merged[:notable]
+		}
+		data.NotablePullRequests = merged
+
+		for _, repo := range repos {
+			data.Repositories = append(data.Repositories, *repo)
+		}
+		sort.Slice(data.Repositories, func(i, j int) bool {
+			a, b := data.Repositories[i], data.Repositories[j]
+			if a.Commits+a.PullRequests+a.Reviews != b.Commits+b.PullRequests+b.Reviews {
+				return a.Commits+a.PullRequests+a.Reviews > b.Commits+b.PullRequests+b.Reviews
+			}
+			return a.Name < b.Name
+		})
+	}
+
+	for _, entry := range rollup.Entries {
+		data.Entries = append(data.Entries, entry.entry())
+	}
+	return data
+}
+
+// bragProjects groups completed issues by project, then by theme, the largest first
+func bragProjects(issues []LinearCompletedIssue, notes map[string]string) []BragProject {
+	themes := make(map[string]map[string][]BragIssue)
+	for _, issue := range issues {
+		project := issue.Project.Name
+		if project == "" {
+			project = bragNoProject
+		}
+		theme := bragNoTheme
+		if len(issue.Labels.Nodes) > 0 {
+			theme = issue.Labels.Nodes[0].Name
+		}
+		completedAt, _ := time.Parse(time.RFC3339, issue.CompletedAt)
+
+		if themes[project] == nil {
+			themes[project] = make(map[string][]BragIssue)
+		}
+		themes[project][theme] = append(themes[project][theme], BragIssue{
+			Identifier:  issue.Identifier,
+			Title:       issue.Title,
+			URL:         issue.URL,
+			CompletedAt: completedAt,
+			Notes:       notes[issue.Identifier],
+		})
+	}
+
+	var projects []BragProject
+	for name, byTheme := range themes {
+		project := BragProject{Name: name}
+		for theme, themeIssues := range byTheme {
+			sort.SliceStable(themeIssues, func(i, j int) bool {
+				return themeIssues[i].CompletedAt.Before(themeIssues[j].CompletedAt)
+			})
+			project.Themes = append(project.Themes, BragTheme{Name: theme, Issues: themeIssues})
+			project.Issues += len(themeIssues)
+		}
+		sort.Slice(project.Themes, func(i, j int) bool {
+			if len(project.Themes[i].Issues) != len(project.Themes[j].Issues) {
+				return len(project.Themes[i].Issues) > len(project.Themes[j].Issues)
+			}
+			return project.Themes[i].Name < project.Themes[j].Name
+		})
+		projects = append(projects, project)
+	}
+
+	// The catch-all project goes last
+	sort.Slice(projects, func(i, j int) bool {
+		if (projects[i].Name == bragNoProject) != (projects[j].Name == bragNoProject) {
+			return projects[j].Name == bragNoProject
+		}
+		if projects[i].Issues != projects[j].Issues {
+			return projects[i].Issues > projects[j].Issues
+		}
+		return projects[i].Name < projects[j].Name
+	})
+	return projects
+}
+
+// BragFilename is the default file name of a brag document covering since to until
+func BragFilename(since time.Time, until time.Time) string {
+	return fmt.Sprintf("brag-%s-to-%s.md", DayKey(since), DayKey(until))
+}
+
+// ResolveBragPath decides where a brag document goes, the same way as ResolveOutputPath
+func ResolveBragPath(output string, dir string, since time.Time, until time.Time) (string, error) {
+	return resolveOutputPath(output, dir, BragFilename(since, until))
+}
+
+// RenderBrag renders a brag document with the template at templatePath or the built-in one
+func RenderBrag(w io.Writer, data BragData, templatePath string) error {
+	tmpl, err := loadTemplate(templatePath, "brag.md.tmpl", defaultBragTemplate)
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render brag document: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package daily
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completedIssue(identifier string, project string, label string, completedAt string) LinearCompletedIssue {
+	issue := LinearCompletedIssue{Identifier: identifier, Title: identifier + " title", CompletedAt: completedAt}
+	issue.Project.Name = project
+	if label != "" {
+		issue.Labels.Nodes = append(issue.Labels.Nodes, struct {
+			Name string `json:"name"`
+		}{Name: label})
+	}
+	return issue
+}
+
+// Test completed issues are grouped by project and theme, with notes from the daily runs
+func Test_BuildBrag_Projects(t *testing.T) {
+	// Arrange
+	since := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)
+	issues := []LinearCompletedIssue{
+		completedIssue("ENG-3", "Billing", "Feature", "2026-05-03T10:00:00Z"),
+		completedIssue("ENG-1", "Billing", "Feature", "2026-04-02T10:00:00Z"),
+		completedIssue("ENG-2", "Billing", "Bug", "2026-04-10T10:00:00Z"),
+		completedIssue("ENG-4", "", "", "2026-06-01T10:00:00Z"),
+		completedIssue("ENG-5", "Auth", "", "2026-06-02T10:00:00Z"),
+	}
+	day := NewDailyExport(SummaryData{
+		Date:   time.Date(2026, 4, 2, 18, 0, 0, 0, time.UTC),
+		Issues: []IssueWithNotes{{Details: LinearIssueDetails{Identifier: "ENG-1", Title: "ENG-1 title"}, UserNotes: "Shipped invoices"}},
+	})
+
+	// Act
+	data := BuildBrag(since, until, nil, issues, []DailyExport{day}, DefaultNotablePullRequests)
+
+	// Assert
+	assert.Equal(t, 5, data.IssuesCompleted)
+	require.Len(t, data.Projects, 3)
+	assert.Equal(t, "Billing", data.Projects[0].Name)
+	assert.Equal(t, 3, data.Projects[0].Issues)
+	require.Len(t, data.Projects[0].Themes, 2)
+	assert.Equal(t, "Feature", data.Projects[0].Themes[0].Name)
+	assert.Equal(t, "ENG-1", data.Projects[0].Themes[0].Issues[0].Identifier)
+	assert.Equal(t, "Shipped invoices", data.Projects[0].Themes[0].Issues[0].Notes)
+	assert.Equal(t, "ENG-3", data.Projects[0].Themes[0].Issues[1].Identifier)
+	assert.Equal(t, "Bug", data.Projects[0].Themes[1].Name)
+	assert.Equal(t, "Auth", data.Projects[1].Name)
+	assert.Equal(t, "General", data.Projects[1].Themes[0].Name)
+	assert.Equal(t, "Other", data.Projects[2].Name)
+	assert.Empty(t, data.NotablePullRequests)
+}
+
+// Test the largest merged pull requests are notable and contributions are counted per repo
+func Test_BuildBrag_PullRequests(t *testing.T) {
+	// Arrange
+	contributions := &GitHubContributions{
+		Username:      "crab",
+		CommitsByRepo: map[string]int{"acme/api": 12, "acme/web": 3},
+		ReviewsByRepo: map[string]int{"acme/web": 4},
+		IssuesByRepo:  map[string]int{"acme/api": 1},
+		PullRequests: []GitHubPullRequest{
+			{Title: "Small", URL: "https://github.com/acme/api/pull/1", RepoOwner: "acme", RepoName: "api", Additions: 5, Deletions: 1, MergedAt: "2026-04-02T10:00:00Z"},
+			{Title: "Large", URL: "https://github.com/acme/api/pull/2", RepoOwner: "acme", RepoName: "api", Additions: 900, Deletions: 100, MergedAt: "2026-04-03T10:00:00Z"},
+			{Title: "Open", URL: "https://github.com/acme/web/pull/3", RepoOwner: "acme", RepoName: "web", Additions: 5000},
+			{Title: "Medium", URL: "https://github.com/acme/web/pull/4", RepoOwner: "acme", RepoName: "web", Additions: 50, Deletions: 50, MergedAt: "2026-04-04T10:00:00Z"},
+		},
+	}
+	day := NewDailyExport(SummaryData{
+		Date: time.Date(2026, 4, 3, 18, 0, 0, 0, time.UTC),
+		GitHub: GitHubActivity{PullRequestsCreated: []GitHubPullRequest{
+			{Title: "Large", URL: "https://github.com/acme/api/pull/2", RepoOwner: "acme", RepoName: "api", Notes: "Rewrote the importer"},
+		}},
+	})
+
+	// Act
+	data := BuildBrag(time.Time{}, time.Time{}, contributions, nil, []DailyExport{day}, 2)
+
+	// Assert
+	assert.Equal(t, "crab", data.Username)
+	assert.Equal(t, 4, data.PullRequestsOpened)
+	assert.Equal(t, 3, data.PullRequestsMerged)
+	assert.Equal(t, 955, data.LinesAdded)
+	assert.Equal(t, 151, data.LinesDeleted)
+	assert.Equal(t, 15, data.Commits)
+	assert.Equal(t, 4, data.Reviews)
+	assert.Equal(t, 1, data.IssuesOpened)
+	require.Len(t, data.NotablePullRequests, 2)
+	assert.Equal(t, "Large", data.NotablePullRequests[0].Title)
+	assert.Equal(t, "Rewrote the importer", data.NotablePullRequests[0].Notes)
+	assert.Equal(t, "Medium", data.NotablePullRequests[1].Title)
+	assert.Equal(t, []BragRepository{
+		{Name: "acme/api", Commits: 12, PullRequests: 2},
+		{Name: "acme/web", Commits: 3, PullRequests: 2, Reviews: 4},
+	}, data.Repositories)
+}
+
+// Test the built-in template renders counts, projects and notable pull requests
+func Test_RenderBrag(t *testing.T) {
+	// Arrange
+	data := BragData{
+		Since:              time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Until:              time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		IssuesCompleted:    1,
+		PullRequestsMerged: 1,
+		Projects: []BragProject{{Name: "Billing", Issues: 1, Themes: []BragTheme{{Name: "Feature", Issues: []BragIssue{
+			{Identifier: "ENG-1", Title: "Invoices", URL: "https://linear.app/ENG-1", Notes: "Shipped invoices"},
+		}}}}},
+		NotablePullRequests: []GitHubPullRequest{
+			{Title: "Importer", URL: "https://github.com/acme/api/pull/2", Number: 2, RepoOwner: "acme", RepoName: "api", Additions: 900, Deletions: 100, ChangedFiles: 12},
+		},
+	}
+	var out bytes.Buffer
+
+	// Act
+	err := RenderBrag(&out, data, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "# Brag Document - April 1, 2026 to June 30, 2026")
+	assert.Contains(t, out.String(), "## Billing (1 issue(s))")
+	assert.Contains(t, out.String(), "### Feature")
+	assert.Contains(t, out.String(), "- [ENG-1: Invoices](https://linear.app/ENG-1)\n  - Shipped invoices")
+	assert.Contains(t, out.String(), "- [acme/api#2: Importer](https://github.com/acme/api/pull/2) - +900 / -100 in 12 file(s)")
+}
+
+// Test completed issues are fetched page by page
+func Test_GetCompletedIssues_Pages(t *testing.T) {
+	// Arrange
+	var queries []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request LinearViewerRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		queries = append(queries, request.Query)
+
+		page := `{"data":{"viewer":{"assignedIssues":{"nodes":[{"identifier":"ENG-1"}],"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}}`
+		if strings.Contains(request.Query, `after: "c1"`) {
+			page = `{"data":{"viewer":{"assignedIssues":{"nodes":[{"identifier":"ENG-2"}],"pageInfo":{"hasNextPage":false}}}}}`
+		}
+		w.Write([]byte(page))
+	}))
+	defer mockServer.Close()
+
+	// Act
+	issues, err := GetCompletedIssues(context.Background(), mockServer.Client(), time.Now().AddDate(0, -3, 0), time.Now(), createTestConfig(mockServer.URL, "test-api-token"))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	assert.Equal(t, "ENG-1", issues[0].Identifier)
+	assert.Equal(t, "ENG-2", issues[1].Identifier)
+	assert.Len(t, queries, 2)
+	assert.Contains(t, queries[0], "completedAt")
+}
+
+// Test long periods are fetched a month at a time, and periods with a full page split in two
+func Test_GetViewerContributions_Chunks(t *testing.T) {
+	// Arrange
+	since := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 40)
+	var periods []string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request GitHubRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		from, to := request.Variables["from"].(string), request.Variables["to"].(string)
+		periods = append(periods, from+" "+to)
+
+		// The first month is busy enough to fill a page
+		count := 1
+		if from == since.Format(time.RFC3339) && to == since.Add(contributionsChunk).Format(time.RFC3339) {
+			count = contributionsPageSize
+		}
+		var nodes []string
+		for i := 0; i < count; i++ {
+			nodes = append(nodes, fmt.Sprintf(`{"pullRequest":{"title":"PR","number":%d,"repository":{"name":"api","owner":{"login":"acme"}}}}`, i))
+		}
+		fmt.Fprintf(w, `{"data":{"viewer":{"login":"crab","contributionsCollection":{
+			"commitContributionsByRepository":[{"repository":{"name":"api","owner":{"login":"acme"}},"contributions":{"totalCount":1,"nodes":[{"occurredAt":%q,"commitCount":5}]}}],
+			"pullRequestReviewContributions":{"nodes":[{"pullRequest":{"url":"https://github.com/acme/web/pull/1"},"occurredAt":%q}]},
+			"pullRequestContributions":{"nodes":[%s]}}}}}`, from, from, strings.Join(nodes, ","))
+	}))
+	defer mockServer.Close()
+
+	previous := githubGraphQLURL
+	githubGraphQLURL = mockServer.URL
+	defer func() { githubGraphQLURL = previous }()
+
+	config := viper.New()
+	config.Set("github.apiToken", "test-token")
+
+	// Act
+	contributions, err := GetViewerContributions(context.Background(), mockServer.Client(), since, until, config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, periods, 4)
+	assert.Equal(t, "crab", contributions.Username)
+	assert.Len(t, contributions.PullRequests, 3)
+	assert.Equal(t, map[string]int{"acme/api": 15}, contributions.CommitsByRepo, "commits are summed per day, not counted in days")
+	require.Len(t, contributions.CommitDays, 3)
+	assert.Equal(t, GitHubCommitDay{Repo: "acme/api", Day: since.Format(time.RFC3339), Commits: 5}, contributions.CommitDays[0])
+	assert.Len(t, contributions.Reviews, 3)
+}
@@ -4,9 +4,13 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package daily
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,114 +27,787 @@ var DailyCmd = &cobra.Command{
 By default, this command looks back 24 hours, but you can customize the time period
 using the --hours flag.
 
+Activity sources are enabled with the "sources" config key (all by default).
+Sources without an API key are skipped. When a source fails, the summary is
+still written from the sources that succeeded, lists what was unavailable, and
+the command exits with status 2.
+
+The summary is rendered with a Go text/template. Set "summary.template" to the
+path of your own template to change its layout. Use --format json or
+--format yaml to export the collected data for other tools instead.
+
+The summary is written to "output.dir" (the current directory by default), to
+the path given with --output, or to stdout with --output -. An existing summary
+is never overwritten silently: you are asked whether to overwrite, append or
+merge, or can choose up front with --on-exists.
+
+Each run saves its data under "data.dir" (~/.local/share/mastercrab by default).
+Running again on the same day skips the issues you already answered (or offers
+your earlier notes as defaults with --revisit) and merges new notes and GitHub
+items into the same summary, replacing the summary file without asking only
+when it is unchanged since the last run. Appending to it only adds what is new
+since the earlier run. Use --fresh to start the day over.
+Every day is also kept in a local history database, see "crab history".
+
+Notes are typed line by line, or composed in $VISUAL/$EDITOR with --editor (or
+"notes.editor: true"); the editor opens on a file describing the issue.
+
+With --time (or "notes.time: true") you are also asked how long you spent on each
+issue you worked on, e.g. 1h30, 45m or 1:30. The default is your earlier answer
+for the day, or an estimate from the commits and pull requests mentioning the
+issue. In the TUI the time is asked after the notes, and t changes it. See
+"crab timesheet" to export the time recorded.
+
+The summary estimates your active time per repository and per Linear issue:
+commit, pull request and review timestamps less than "sessions.gap" apart (2h by
+default) make up a work session, which starts "sessions.leadIn" (30m by default)
+before its first timestamp.
+
+With --timeline the activity is only printed in chronological order: commits,
+pull requests, reviews, issues, and Linear comments and state changes, in the
+"timezone" config key's time zone (e.g. Europe/Paris, local time by default).
+Nothing is asked or recorded. Set "summary.timeline: true" to also add a
+Timeline section to the summary.
+
+GitHub pull requests, reviews and issues are offered after the Linear issues:
+add notes to the ones that deserve context, or leave noise such as dependency
+bumps out of the summary.
+
+With --tui (or "ui.tui: true") the day's issues and GitHub activity are listed in
+a full-screen terminal UI. Move freely between them, mark each item as worked
+on (y), not worked on or left out (n) or skipped (s), edit notes (e) and press
+q to write the summary.
+
+For cron and CI, --no-input includes every fetched issue without prompting,
+keeping the notes already captured for the day. --answers file.yaml supplies
+notes per Linear identifier, with or without --no-input:
+
+  ENG-123: Fixed the retry loop
+  ENG-124:
+    worked: false
+  ENG-125:
+    notes: Paired on the migration
+    time: 1h30
+
+Before the summary is written, you are asked for anything else you did, such as
+meetings, interviews or incident calls, each with an optional category. These
+are listed as other work.
+
+With --format standup the summary becomes a compact Yesterday / Today / Blockers
+update to paste into chat. You are asked for today's plan, with the Linear issues
+in progress and your open pull requests suggested, and for blockers: pick the
+Linear issues that are blocked and say why, or describe anything else in your way.
+Set "standup.template" to render it with your own template. With --no-input the
+suggestions become today's plan.
+
+Notes captured during the day with "crab note" are offered as the notes of
+their Linear issue, and notes about anything else are listed as other work.
+
+Every answer is saved as soon as it is given. If a run is interrupted before the
+summary is written, the next run offers to resume where it stopped. Without a
+terminal to ask, it starts over unless --resume is given.
+
 Example:
   mastercrab daily              # Summary for the last 24 hours
-  mastercrab daily --hours 48   # Summary for the last 48 hours`,
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("📅 Daily Work Summary Generator")
-		fmt.Println(strings.Repeat("═", 80))
+  mastercrab daily --hours 48   # Summary for the last 48 hours
+  mastercrab daily --timeline   # The shape of the day, in order
+  mastercrab daily --format json # Export the day's data as JSON
+  mastercrab daily --format standup -o - | pbcopy # Standup update for chat
+  mastercrab daily -o - | pbcopy # Write the summary to stdout
+  mastercrab daily --no-input --answers notes.yaml # End-of-day draft from cron`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := viper.GetViper()
+
+		// Keep stdout for the summary itself when it is piped
+		output, _ := cmd.Flags().GetString("output")
+		if output == StdoutPath {
+			console = os.Stderr
+		}
+
+		fmt.Fprintln(console, "📅 Daily Work Summary Generator")
+		fmt.Fprintln(console, strings.Repeat("═", 80))
 
 		// Get the lookback hours from flag, defaulting to 24 hours
 		lookbackHours, _ := cmd.Flags().GetInt("hours")
 
-		// Calculate time period
-		until := time.Now()
-		since := until.Add(-time.Duration(lookbackHours) * time.Hour)
+		formatFlag, _ := cmd.Flags().GetString("format")
+		format, err := ParseFormat(formatFlag)
+		if err != nil {
+			fmt.Fprintf(console, "❌ %s\n", err)
+			return err
+		}
 
-		// Convert to Linear date filter format (ISO 8601 duration)
-		linearDateFilter := fmt.Sprintf("-P%dD", lookbackHours/24)
-		if lookbackHours < 24 {
-			linearDateFilter = "-P1D" // Linear doesn't support hourly granularity well
+		loc, err := TimeZone(config)
+		if err != nil {
+			fmt.Fprintf(console, "❌ %s\n", err)
+			return err
 		}
 
-		// Create HTTP client
-		client := &http.Client{}
+		// Build on earlier runs from today unless asked to start over
+		today := time.Now()
+		fresh, _ := cmd.Flags().GetBool("fresh")
+		revisit, _ := cmd.Flags().GetBool("revisit")
+		useEditor, _ := cmd.Flags().GetBool("editor")
+		if !cmd.Flags().Changed("editor") {
+			useEditor = config.GetBool("notes.editor")
+		}
+		askTime, _ := cmd.Flags().GetBool("time")
+		if !cmd.Flags().Changed("time") {
+			askTime = config.GetBool("notes.time")
+		}
+		estimator := NewWorkSessionEstimator(config)
+		useTUI, _ := cmd.Flags().GetBool("tui")
+		if !cmd.Flags().Changed("tui") {
+			useTUI = config.GetBool("ui.tui")
+		}
+		noInput, _ := cmd.Flags().GetBool("no-input")
+		if useTUI && !noInput && !isTerminal(os.Stdin) {
+			fmt.Fprintln(console, "⚠️  Not running in a terminal, answering issues line by line instead of in the TUI")
+			useTUI = false
+		}
+
+		var answers Answers
+		if answersPath, _ := cmd.Flags().GetString("answers"); answersPath != "" {
+			answers, err = LoadAnswers(answersPath)
+			if err != nil {
+				fmt.Fprintf(console, "❌ %s\n", err)
+				return err
+			}
+		}
+
+		dataDir, err := DataDir(config)
+		if err != nil {
+			fmt.Fprintf(console, "❌ %s\n", err)
+			return err
+		}
+
+		// Calculate time period
+		window := Window{
+			Since: today.Add(-time.Duration(lookbackHours) * time.Hour),
+			Until: today,
+		}
 
-		// Fetch GitHub activity
-		fmt.Printf("\n🔍 Fetching GitHub activity from the last %d hours...\n", lookbackHours)
-		githubActivity, err := GetViewerActivity(client, since, until, viper.GetViper())
+		// The timeline only shows the activity, nothing is asked or recorded
+		if timeline, _ := cmd.Flags().GetBool("timeline"); timeline {
+			return runTimeline(cmd, config, window, loc)
+		}
+
+		// Pick up a session that was interrupted before its summary was written
+		session, err := LoadSession(dataDir)
 		if err != nil {
-			fmt.Printf("⚠️  Failed to fetch GitHub activity: %s\n", err)
-			// Continue with Linear even if GitHub fails
+			fmt.Fprintf(console, "⚠️  %s\n", err)
+		}
+		resume, _ := cmd.Flags().GetBool("resume")
+		resumeSet := cmd.Flags().Changed("resume")
+		if session != nil && noInput {
+			// Leave the unfinished session for the next interactive run
+			fmt.Fprintln(console, "💾 An unfinished session was left for the next interactive run")
+			session = NewSession(dataDir, today, window)
+		} else if session != nil && !resumeSet && !isTerminal(os.Stdin) {
+			// Nobody is there to answer, piped answers are not for this question
+			fmt.Fprintln(console, "💾 Starting over without a terminal to ask, use --resume to pick up the unfinished session instead")
+			session = NewSession(dataDir, today, window)
+		} else if session != nil && (resume || !resumeSet && PromptResumeSession(stdin.WithContext(cmd.Context()), session)) {
+			today = session.StartedAt
+			window = Window{Since: session.Window.Since, Until: session.Window.Until}
+			fmt.Fprintf(console, "▶️  Resuming, %d answer(s) restored\n", len(session.Answers))
 		} else {
-			fmt.Printf("✅ Found GitHub activity: %d commits, %d PRs, %d reviews, %d issues\n",
-				githubActivity.TotalCommits,
-				githubActivity.TotalPullRequests,
-				githubActivity.TotalReviews,
-				githubActivity.TotalIssues)
+			if session != nil {
+				if err := session.Discard(); err != nil {
+					fmt.Fprintf(console, "⚠️  %s\n", err)
+				}
+			}
+			session = NewSession(dataDir, today, window)
 		}
 
-		// Fetch Linear assigned issues
-		fmt.Printf("\n🔍 Fetching Linear issues updated in the last %d hours...\n", lookbackHours)
-		linearViewer, err := GetViewerAssignedIssues(client, linearDateFilter, viper.GetViper())
+		var previous *DailyExport
+		if !fresh {
+			previous, err = LoadDayRecord(dataDir, today)
+			if err != nil {
+				fmt.Fprintf(console, "⚠️  Ignoring today's earlier run: %s\n", err)
+			}
+		}
+		answered := map[string]string{}
+		previousTime := map[string]time.Duration{}
+		if previous != nil {
+			answered = previous.AnsweredIssues()
+			for _, issue := range previous.Issues {
+				previousTime[issue.ID] = issue.timeSpent()
+			}
+			fmt.Fprintf(console, "\n🔁 Continuing today's earlier run (%d issue(s) already answered)\n", len(answered))
+		}
+
+		// Notes captured during the day with "crab note"
+		journal, err := LoadJournal(dataDir, window)
 		if err != nil {
-			fmt.Printf("❌ Failed to fetch assigned issues: %s\n", err)
-			return
+			fmt.Fprintf(console, "⚠️  Ignoring your captured notes: %s\n", err)
+		}
+		if len(journal) > 0 {
+			fmt.Fprintf(console, "📓 %d note(s) captured during the day\n", len(journal))
 		}
 
-		// Display the results
-		issues := linearViewer.Viewer.AssignedIssues.Edges
-		if len(issues) == 0 && githubActivity.TotalCommits == 0 &&
-			githubActivity.TotalPullRequests == 0 && githubActivity.TotalReviews == 0 &&
-			githubActivity.TotalIssues == 0 {
-			fmt.Println("✅ No activity found in the specified time period")
-			return
+		// Decide where the summary goes before any time is spent on notes
+		summaryPath, err := ResolveOutputPath(output, config.GetString("output.dir"), today, format)
+		if err != nil {
+			fmt.Fprintf(console, "❌ %s\n", err)
+			return err
+		}
+		onExistsFlag, _ := cmd.Flags().GetString("on-exists")
+		if !cmd.Flags().Changed("on-exists") && config.IsSet("output.onExists") {
+			onExistsFlag = config.GetString("output.onExists")
+		}
+		onExists, err := ParseExistingAction(onExistsFlag)
+		if err != nil {
+			fmt.Fprintf(console, "❌ %s\n", err)
+			return err
+		}
+		if previous != nil && onExists == ExistingAsk && UnchangedSinceRender(dataDir, summaryPath) {
+			// The earlier run's data is merged in, and the file holds nothing else
+			onExists = ExistingOverwrite
+		}
+		if _, statErr := os.Stat(summaryPath); summaryPath != StdoutPath && statErr == nil && onExists == ExistingAsk {
+			if noInput || !isTerminal(os.Stdin) {
+				err := fmt.Errorf("%s already exists, use --on-exists to overwrite, append or merge", summaryPath)
+				fmt.Fprintf(console, "❌ %s\n", err)
+				return err
+			}
+			onExists, err = PromptExistingAction(stdin.WithContext(cmd.Context()), summaryPath)
+			if err != nil {
+				return err
+			}
+			if onExists == ExistingCancel {
+				fmt.Fprintln(console, "🚫 Cancelled, the existing summary was left untouched")
+				return nil
+			}
 		}
 
-		fmt.Printf("✅ Found %d Linear issue(s) updated in the last %d hours\n", len(issues), lookbackHours)
+		// Cancel outstanding work on Ctrl-C or a closed terminal; a second Ctrl-C exits immediately
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
 
-		// Interactive flow: fetch details and prompt for notes
-		var issuesWithNotes []IssueWithNotes
+		// Create HTTP client
+		client := NewHTTPClient(config)
 
-		for i, edge := range issues {
-			fmt.Printf("\n\n📦 Processing issue %d of %d...\n", i+1, len(issues))
+		// Fetch activity from every enabled source
+		sources, unconfigured := EnabledSources(config)
+		for _, source := range unconfigured {
+			fmt.Fprintf(console, "\n⏭️  Skipping %s: no API key configured\n", source.Name())
+		}
 
-			// Fetch detailed information for this issue
-			details, err := GetIssueDetails(client, edge.Node.ID, viper.GetViper())
-			if err != nil {
-				fmt.Printf("⚠️  Failed to fetch details for issue %s: %s\n", edge.Node.ID, err)
-				fmt.Println("   Skipping this issue...")
+		if len(sources) == 0 {
+			fmt.Fprintln(console, "\n❌ No activity sources are configured, add an API key to your config file")
+			return nil
+		}
+
+		fmt.Fprintf(console, "\n🔍 Fetching activity from the last %d hours: %s\n", lookbackHours, strings.Join(sourceNames(sources), ", "))
+		fetches := FetchAll(ctx, client, sources, window, config, printFetchProgress)
+
+		unavailable := UnavailableSources(fetches)
+
+		var items []ActivityItem
+		var githubActivity GitHubActivity
+		for _, fetch := range fetches {
+			if fetch.Err != nil {
 				continue
 			}
 
-			// Display the issue details
-			DisplayIssueDetails(details)
+			items = append(items, fetch.Result.Items...)
 
-			// Prompt for user notes
-			notes, workedOnIt, err := PromptForNotes(details)
-			if err != nil {
-				// User skipped, continue to next issue
-				fmt.Println("⏭️  Skipped")
+			if activity, ok := fetch.Result.Data.(GitHubActivity); ok {
+				githubActivity = activity
+			}
+		}
+
+		// Free-form entries and captured notes can still make a summary without any activity
+		if len(items) == 0 {
+			if len(unavailable) > 0 {
+				fmt.Fprintln(console, "❌ No activity could be fetched")
+			} else {
+				fmt.Fprintln(console, "✅ No activity found in the specified time period")
+			}
+		}
+
+		issues := ItemsOfKind(items, ItemKindLinearIssue)
+
+		// Issues answered in a resumed session are never asked again
+		resumed := session.Answered()
+		if len(resumed) > 0 {
+			var remaining []ActivityItem
+			for _, issue := range issues {
+				if _, ok := resumed[issue.ID]; !ok {
+					remaining = append(remaining, issue)
+				}
+			}
+			issues = remaining
+		}
+
+		if !revisit && len(answered) > 0 {
+			var unanswered []ActivityItem
+			for _, issue := range issues {
+				if _, ok := answered[issue.ID]; !ok {
+					unanswered = append(unanswered, issue)
+				}
+			}
+			if skipped := len(issues) - len(unanswered); skipped > 0 {
+				fmt.Fprintf(console, "\n⏭️  Skipping %d issue(s) already answered earlier today (use --revisit to go over them again)\n", skipped)
+			}
+			issues = unanswered
+		}
+
+		// GitHub pull requests, reviews and issues can be annotated or left out the same way
+		answeredItems := map[string]string{}
+		if previous != nil {
+			answeredItems = previous.AnsweredItems()
+		}
+		resumedItems := session.ItemAnswers()
+		var githubItems []ActivityItem
+		for _, item := range items {
+			switch item.Kind {
+			case ItemKindPullRequest, ItemKindReview, ItemKindIssue:
+			default:
+				continue
+			}
+			if _, ok := resumedItems[item.URL]; ok {
+				continue
+			}
+			if _, ok := answeredItems[item.URL]; ok && !revisit {
 				continue
 			}
+			githubItems = append(githubItems, item)
+		}
+
+		// Interactive flow: fetch details and prompt for notes
+		issuesWithNotes, notWorked := session.Results()
+
+		// Fetch details for upcoming issues while the user answers the current one
+		prefetched := prefetchIssueDetails(ctx, client, config, issues)
+
+		// Identifiers of the issues reviewed in this run, whose notes include the journal already
+		reviewed := make(map[string]bool)
+
+		interrupted := false
+		if len(items) == 0 {
+			// Nothing was fetched, so there is nothing to review
+		} else if noInput {
+			// Without prompts every issue is included, with the notes prepared for it
+			for fetched := range prefetched {
+				if fetched.Err != nil {
+					fmt.Fprintf(console, "⚠️  Failed to fetch details for issue %s: %s\n", fetched.Item.ID, fetched.Err)
+					unavailable = append(unavailable, SourceStatus{
+						Source: fmt.Sprintf("linear (%s)", fetched.Item.Title),
+						Reason: fetched.Err.Error(),
+					})
+					continue
+				}
+
+				reviewed[fetched.Details.Identifier] = true
+				answer, ok := answers.Lookup(fetched.Details.Identifier)
+				if !ok {
+					answer = Answer{Worked: true}
+				}
+				if answer.Worked {
+					notes := MergeNotes(journal.NotesFor(fetched.Details.Identifier), answer.Notes)
+					issuesWithNotes = append(issuesWithNotes, IssueWithNotes{Details: fetched.Details, UserNotes: notes, TimeSpent: answer.TimeSpent})
+				} else {
+					notWorked = append(notWorked, fetched.Details)
+				}
+			}
+			fmt.Fprintf(console, "\n📋 Included %d issue(s) without prompting\n", len(issuesWithNotes))
+		} else if useTUI {
+			activity := append(append([]ActivityItem{}, githubItems...), ItemsOfKind(items, ItemKindCommits)...)
+			previousNotes := make(map[string]string)
+			for id, notes := range answered {
+				previousNotes[id] = notes
+			}
+			for url, notes := range answeredItems {
+				previousNotes[url] = notes
+			}
+
+			entries, stopped, err := RunReviewTUI(ctx, ReviewOptions{
+				Issues:        issues,
+				Details:       prefetched,
+				Activity:      activity,
+				PreviousNotes: previousNotes,
+				Journal:       journal,
+				Answers:       answers,
+				UseEditor:     useEditor,
+				AskTime:       askTime,
+				TimeDefault: func(details LinearIssueDetails) time.Duration {
+					if spent := previousTime[details.ID]; spent > 0 {
+						return spent
+					}
+					return estimator.IssueTime(details.Identifier, githubActivity)
+				},
+				Session: session,
+			})
+			if err != nil {
+				fmt.Fprintf(console, "⚠️  The review screen failed: %s\n", err)
+			}
+			interrupted = stopped
+
+			for _, entry := range entries {
+				if entry.Loaded && entry.Err == nil {
+					reviewed[entry.Details.Identifier] = true
+				}
+			}
 
-			if workedOnIt {
-				issuesWithNotes = append(issuesWithNotes, IssueWithNotes{
-					Details:   details,
-					UserNotes: notes,
-				})
-				fmt.Println("✅ Notes recorded!")
+			worked, notWorkedInTUI, unavailableInTUI := ReviewResults(entries)
+			issuesWithNotes = append(issuesWithNotes, worked...)
+			notWorked = append(notWorked, notWorkedInTUI...)
+			unavailable = append(unavailable, unavailableInTUI...)
+		} else {
+			processed := 0
+			for fetched := range prefetched {
+				if ctx.Err() != nil {
+					break
+				}
+
+				processed++
+				fmt.Fprintf(console, "\n\n📦 Processing issue %d of %d...\n", processed, len(issues))
+
+				if fetched.Err != nil {
+					fmt.Fprintf(console, "⚠️  Failed to fetch details for issue %s: %s\n", fetched.Item.ID, fetched.Err)
+					fmt.Fprintln(console, "   Skipping this issue...")
+					unavailable = append(unavailable, SourceStatus{
+						Source: fmt.Sprintf("linear (%s)", fetched.Item.Title),
+						Reason: fetched.Err.Error(),
+					})
+					continue
+				}
+				details := fetched.Details
+
+				reviewed[details.Identifier] = true
+
+				// Issues answered in the answers file are not asked
+				if answer, ok := answers.Lookup(details.Identifier); ok {
+					fmt.Fprintf(console, "📋 %s: %s (answered in the answers file)\n", details.Identifier, details.Title)
+					saveAnswer(session, details, answer.Notes, answer.Worked, false)
+					if answer.Worked {
+						if answer.TimeSpent > 0 {
+							if err := session.RecordTimeSpent(details, answer.TimeSpent); err != nil {
+								fmt.Fprintf(console, "⚠️  Your answer could not be saved for resuming: %s\n", err)
+							}
+						}
+						issuesWithNotes = append(issuesWithNotes, IssueWithNotes{Details: details, UserNotes: answer.Notes, TimeSpent: answer.TimeSpent})
+					} else {
+						notWorked = append(notWorked, details)
+					}
+					continue
+				}
+
+				// Display the issue details
+				DisplayIssueDetails(details)
+
+				// Prompt for user notes
+				defaults := MergeNotes(answered[details.ID], journal.NotesFor(details.Identifier))
+				notes, workedOnIt, err := promptForNotes(ctx, details, defaults, useEditor)
+				if ctx.Err() != nil {
+					break
+				}
+				if err != nil {
+					// User skipped, continue to next issue
+					fmt.Fprintln(console, "⏭️  Skipped")
+					saveAnswer(session, details, "", false, true)
+					continue
+				}
+				saveAnswer(session, details, notes, workedOnIt, false)
+
+				if workedOnIt {
+					issuesWithNotes = append(issuesWithNotes, IssueWithNotes{
+						Details:   details,
+						UserNotes: notes,
+					})
+					fmt.Fprintln(console, "✅ Notes recorded!")
+
+					if askTime {
+						defaultSpent := previousTime[details.ID]
+						if defaultSpent == 0 {
+							defaultSpent = estimator.IssueTime(details.Identifier, githubActivity)
+						}
+						spent, err := PromptForTimeSpent(stdin.WithContext(ctx), defaultSpent)
+						if ctx.Err() != nil {
+							break
+						}
+						if err != nil {
+							fmt.Fprintf(console, "⚠️  Could not read the time spent: %s\n", err)
+							continue
+						}
+						issuesWithNotes[len(issuesWithNotes)-1].TimeSpent = spent
+						if err := session.RecordTimeSpent(details, spent); err != nil {
+							fmt.Fprintf(console, "⚠️  Your answer could not be saved for resuming: %s\n", err)
+						}
+					}
+				} else {
+					notWorked = append(notWorked, details)
+					fmt.Fprintln(console, "➖ No work recorded for this issue")
+				}
+			}
+
+			for i, item := range githubItems {
+				if ctx.Err() != nil {
+					break
+				}
+
+				fmt.Fprintf(console, "\n\n📦 Processing GitHub item %d of %d...\n", i+1, len(githubItems))
+				DisplayItemDetails(item)
+
+				notes, include, err := PromptForItemNotes(stdin.WithContext(ctx), item, answeredItems[item.URL], useEditor)
+				if ctx.Err() != nil {
+					break
+				}
+				if err != nil {
+					// Skipped items stay in the summary as they are
+					fmt.Fprintln(console, "⏭️  Skipped")
+					saveItemAnswer(session, item, "", false)
+					continue
+				}
+				saveItemAnswer(session, item, notes, !include)
+
+				if include {
+					fmt.Fprintln(console, "✅ Notes recorded!")
+				} else {
+					fmt.Fprintln(console, "🚫 Left out of the summary")
+				}
+			}
+		}
+		githubActivity = githubActivity.WithAnswers(session.ItemAnswers())
+
+		if ctx.Err() != nil || interrupted {
+			fmt.Fprintln(console, "\n🛑 Interrupted, writing the notes recorded so far")
+		} else if !noInput {
+			// Meetings, interviews and the like are not part of any source
+			promptForEntries(ctx, session)
+		}
+
+		summaryData := SummaryData{
+			Date:        today,
+			Window:      window,
+			Issues:      issuesWithNotes,
+			GitHub:      githubActivity,
+			Unavailable: unavailable,
+			Sources:     SourceReports(fetches, unconfigured),
+			NotWorked:   notWorked,
+			Entries:     session.FreeFormEntries(),
+		}
+		summaryData.ActiveTime = estimator.Estimate(githubActivity, issuesWithNotes)
+		if config.GetBool("summary.timeline") {
+			details := make([]LinearIssueDetails, 0, len(issuesWithNotes))
+			for _, issue := range issuesWithNotes {
+				details = append(details, issue.Details)
+			}
+			summaryData.Timeline = BuildTimeline(githubActivity, details, window, loc)
+		}
+		if previous != nil {
+			summaryData = MergeExports(*previous, NewDailyExport(summaryData)).SummaryData()
+		}
+		ApplyJournal(&summaryData, journal, reviewed)
+		if summaryData.Empty() && len(summaryData.NotWorked) == 0 {
+			if len(unavailable) > 0 {
+				return NothingFetchedError(unavailable)
+			}
+			fmt.Fprintln(console, "\n✅ Nothing to summarise")
+			return nil
+		}
+		if format == FormatStandup && ctx.Err() == nil && !interrupted {
+			// What was done is yesterday's part of the standup, today's plan and blockers are asked
+			var plan []PlanItem
+			var blockers []Blocker
+			if noInput {
+				plan = StandupSuggestions(summaryData)
 			} else {
-				fmt.Println("➖ No work recorded for this issue")
+				plan, blockers = promptForStandup(ctx, summaryData)
 			}
+			summaryData.Plan = mergeByKey(summaryData.Plan, plan, PlanItem.key)
+			summaryData.Blockers = mergeByKey(summaryData.Blockers, blockers, Blocker.key)
 		}
+		// Generate the summary
+		fmt.Fprintf(console, "\n📄 Generating summary: %s\n", describeOutput(summaryPath))
 
-		// Generate the markdown summary
-		summaryFilename := fmt.Sprintf("daily-summary-%s.md", time.Now().Format("2006-01-02"))
-		fmt.Printf("\n📄 Generating summary file: %s\n", summaryFilename)
+		record := NewDailyExport(summaryData)
+		if err := RecordHistory(dataDir, today, record); err != nil {
+			fmt.Fprintf(console, "⚠️  Failed to add today to your history: %s\n", err)
+		} else if !noInput {
+			// The answers are part of today's data now, the session is no longer needed
+			if err := session.Discard(); err != nil {
+				fmt.Fprintf(console, "⚠️  %s\n", err)
+			}
+		}
+		templatePath := config.GetString("summary.template")
+		if format == FormatStandup {
+			templatePath = config.GetString("standup.template")
+		}
+		written, nothingNew := summaryData, false
+		if _, statErr := os.Stat(summaryPath); previous != nil && onExists == ExistingAppend && summaryPath != StdoutPath && statErr == nil {
+			// The earlier run's summary is already in the file, only what is new is appended
+			written = NewSince(*previous, record).SummaryData()
+			nothingNew = written.Empty() && len(written.NotWorked) == 0 && len(written.Plan) == 0 && len(written.Blockers) == 0
+		}
+		if nothingNew {
+			fmt.Fprintln(console, "✅ Nothing new since the earlier run, the summary was left as it was")
+		} else if err := WriteSummaryOutput(written, format, templatePath, summaryPath, onExists); err != nil {
+			fmt.Fprintf(console, "❌ Failed to generate summary: %s\n", err)
+			return err
+		} else if summaryPath != StdoutPath {
+			if err := RecordRender(dataDir, summaryPath); err != nil {
+				fmt.Fprintf(console, "⚠️  %s\n", err)
+			}
+		}
 
-		err = GenerateSimplifiedMarkdownSummary(issuesWithNotes, githubActivity, summaryFilename)
-		if err != nil {
-			fmt.Printf("❌ Failed to generate summary: %s\n", err)
-			return
+		if len(unavailable) > 0 {
+			fmt.Fprintf(console, "\n⚠️  Partial summary generated, %d source(s) unavailable:\n", len(unavailable))
+			for _, status := range unavailable {
+				fmt.Fprintf(console, "   - %s: %s\n", status.Source, status.Reason)
+			}
+			fmt.Fprintf(console, "📂 File: %s\n", describeOutput(summaryPath))
+			return &PartialRunError{Unavailable: unavailable}
 		}
 
-		fmt.Printf("\n✨ Summary generated successfully!\n")
-		fmt.Printf("📂 File: %s\n", summaryFilename)
+		fmt.Fprintf(console, "\n✨ Summary generated successfully!\n")
+		fmt.Fprintf(console, "📂 File: %s\n", describeOutput(summaryPath))
+		return nil
 	},
 }
 
+// runTimeline fetches the activity of the window and prints it as a timeline, with the
+// comments and state changes of the Linear issues
+func runTimeline(cmd *cobra.Command, config *viper.Viper, window Window, loc *time.Location) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+	defer stop()
+
+	client := NewHTTPClient(config)
+	sources, unconfigured := EnabledSources(config)
+	for _, source := range unconfigured {
+		fmt.Fprintf(console, "\n⏭️  Skipping %s: no API key configured\n", source.Name())
+	}
+	if len(sources) == 0 {
+		fmt.Fprintln(console, "\n❌ No activity sources are configured, add an API key to your config file")
+		return nil
+	}
+
+	fmt.Fprintf(console, "\n🔍 Fetching activity from the last %d hours: %s\n", window.Hours(), strings.Join(sourceNames(sources), ", "))
+	fetches := FetchAll(ctx, client, sources, window, config, printFetchProgress)
+	unavailable := UnavailableSources(fetches)
+
+	var activity GitHubActivity
+	var issues []ActivityItem
+	for _, fetch := range fetches {
+		if fetch.Err != nil {
+			continue
+		}
+		issues = append(issues, ItemsOfKind(fetch.Result.Items, ItemKindLinearIssue)...)
+		if data, ok := fetch.Result.Data.(GitHubActivity); ok {
+			activity = data
+		}
+	}
+
+	// Comments and state changes are only part of each issue's details
+	var details []LinearIssueDetails
+	for fetched := range prefetchIssueDetails(ctx, client, config, issues) {
+		if fetched.Err != nil {
+			fmt.Fprintf(console, "⚠️  Failed to fetch details for issue %s: %s\n", fetched.Item.ID, fetched.Err)
+			unavailable = append(unavailable, SourceStatus{
+				Source: fmt.Sprintf("linear (%s)", fetched.Item.Title),
+				Reason: fetched.Err.Error(),
+			})
+			continue
+		}
+		details = append(details, fetched.Details)
+	}
+
+	RenderTimeline(cmd.OutOrStdout(), BuildTimeline(activity, details, window, loc), loc)
+	if len(unavailable) > 0 {
+		return &PartialRunError{Unavailable: unavailable}
+	}
+	return nil
+}
+
+// prefetchIssueDetails fetches the details of the Linear issues, "linear.prefetch" at a time
+func prefetchIssueDetails(ctx context.Context, client *http.Client, config *viper.Viper, issues []ActivityItem) <-chan PrefetchedIssue {
+	fetchDetails := func(ctx context.Context, issueID string) (LinearIssueDetails, error) {
+		ctx, cancel := context.WithTimeout(ctx, SourceTimeout("linear", config))
+		defer cancel()
+		return GetIssueDetails(ctx, client, issueID, config)
+	}
+	prefetch := config.GetInt("linear.prefetch")
+	if prefetch <= 0 {
+		prefetch = DefaultPrefetch
+	}
+	return PrefetchIssueDetails(ctx, issues, prefetch, fetchDetails)
+}
+
+// printFetchProgress reports each finished source and the ones still pending
+func printFetchProgress(done SourceFetch, pending []string) {
+	if done.Err != nil {
+		fmt.Fprintf(console, "⚠️  Failed to fetch %s activity: %s\n", done.Source.Name(), done.Err)
+	} else {
+		fmt.Fprintf(console, "✅ Found %s activity: %s (%s)\n", done.Source.Name(), done.Result.Summary, done.Duration.Round(time.Millisecond))
+	}
+
+	if len(pending) > 0 {
+		fmt.Fprintf(console, "   ⏳ Still waiting for: %s\n", strings.Join(pending, ", "))
+	}
+}
+
+// promptForNotes runs PromptForNotesWithDefault on stdin, returning as soon as ctx is cancelled
+func promptForNotes(ctx context.Context, issue LinearIssueDetails, previousNotes string, useEditor bool) (string, bool, error) {
+	return PromptForNotesWithDefault(stdin.WithContext(ctx), issue, previousNotes, useEditor)
+}
+
+// saveAnswer records an answer in the session, warning when it cannot be persisted
+func saveAnswer(session *Session, issue LinearIssueDetails, notes string, worked bool, skipped bool) {
+	if err := session.Record(issue, notes, worked, skipped); err != nil {
+		fmt.Fprintf(console, "⚠️  Your answer could not be saved for resuming: %s\n", err)
+	}
+}
+
+// saveItemAnswer records the answer for a GitHub item in the session, warning when it cannot be persisted
+func saveItemAnswer(session *Session, item ActivityItem, notes string, excluded bool) {
+	if err := session.RecordItem(item, notes, excluded); err != nil {
+		fmt.Fprintf(console, "⚠️  Your answer could not be saved for resuming: %s\n", err)
+	}
+}
+
+// describeOutput names the summary destination for progress messages
+func describeOutput(path string) string {
+	if path == StdoutPath {
+		return "stdout"
+	}
+	return path
+}
+
+// isTerminal reports whether the file is an interactive terminal
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func sourceNames(sources []ActivitySource) []string {
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = source.Name()
+	}
+	return names
+}
+
 func init() {
 	// Add flag for configurable time period (in hours)
 	DailyCmd.Flags().IntP("hours", "H", 24, "Number of hours to look back for activity (default: 24)")
+	DailyCmd.Flags().StringP("format", "f", FormatMarkdown, "Output format: markdown, json, yaml or standup")
+	DailyCmd.Flags().StringP("output", "o", "", "Summary file or directory, or - for stdout (default: output.dir or the current directory)")
+	DailyCmd.Flags().BoolP("tui", "t", false, "Review the day's activity in a full-screen terminal UI (config: ui.tui)")
+	DailyCmd.Flags().BoolP("editor", "e", false, "Write notes in $VISUAL/$EDITOR instead of line by line (config: notes.editor)")
+	DailyCmd.Flags().Bool("time", false, "Also ask how long you spent on each issue (config: notes.time)")
+	DailyCmd.Flags().Bool("timeline", false, "Only print the activity as a chronological timeline, without asking or writing anything")
+	DailyCmd.Flags().Bool("no-input", false, "Include every fetched issue without prompting, for cron and CI")
+	DailyCmd.Flags().String("answers", "", "YAML file of notes per Linear identifier, used instead of prompting")
+	DailyCmd.Flags().Bool("resume", false, "Resume an unfinished session without asking, or start over with --resume=false")
+	DailyCmd.Flags().Bool("fresh", false, "Ignore today's earlier runs instead of building on them")
+	DailyCmd.Flags().Bool("revisit", false, "Go over issues answered in an earlier run today, with those notes as defaults")
+	DailyCmd.Flags().String("on-exists", ExistingAsk, "When the summary file exists: ask, overwrite, append, merge or cancel")
 }
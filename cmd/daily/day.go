@@ -0,0 +1,232 @@
+package daily
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DataDir returns where mastercrab keeps its local data: the "data.dir" config key,
+// $XDG_DATA_HOME/mastercrab, or ~/.local/share/mastercrab
+func DataDir(config *viper.Viper) (string, error) {
+	if dir := config.GetString("data.dir"); dir != "" {
+		return expandHome(dir)
+	}
+	if xdg := os.Getenv("XDG_DATA_HOME"); xdg != "" {
+		return filepath.Join(xdg, "mastercrab"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".local", "share", "mastercrab"), nil
+}
+
+// DayKey is the key a day's data is stored under
+func DayKey(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+// LoadDayRecord reads the data saved by earlier runs on the same day from the history.
+// It returns nil when nothing was saved yet.
+func LoadDayRecord(dataDir string, date time.Time) (*DailyExport, error) {
+	history, err := OpenHistory(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer history.Close()
+
+	return history.Get(date)
+}
+
+// AnsweredIssues maps the Linear issue IDs answered in the record to the notes given.
+// Issues answered "no" map to an empty string.
+func (e DailyExport) AnsweredIssues() map[string]string {
+	answered := make(map[string]string)
+	for _, issue := range e.NotWorked {
+		answered[issue.ID] = ""
+	}
+	for _, issue := range e.Issues {
+		answered[issue.ID] = issue.Notes
+	}
+	return answered
+}
+
+// AnsweredItems maps the URLs of the GitHub items in the record to the notes given.
+// Items left out of the summary map to an empty string.
+func (e DailyExport) AnsweredItems() map[string]string {
+	answered := make(map[string]string)
+	for _, url := range e.GitHub.Excluded {
+		answered[url] = ""
+	}
+	for _, pr := range append(append([]ExportPullRequest{}, e.GitHub.PullRequests...), e.GitHub.Reviews...) {
+		answered[pr.URL] = pr.Notes
+	}
+	for _, issue := range e.GitHub.Issues {
+		answered[issue.URL] = issue.Notes
+	}
+	return answered
+}
+
+// SummaryData converts an export back into the data model used for rendering
+func (e DailyExport) SummaryData() SummaryData {
+	data := SummaryData{
+		Date:   e.GeneratedAt,
+		Window: Window{Since: e.Window.Since, Until: e.Window.Until},
+		Days:   e.Days,
+		GitHub: GitHubActivity{
+			Username:          e.GitHub.Username,
+			TotalCommits:      e.GitHub.Totals.Commits,
+			TotalIssues:       e.GitHub.Totals.Issues,
+			TotalPullRequests: e.GitHub.Totals.PullRequests,
+			TotalReviews:      e.GitHub.Totals.Reviews,
+			CommitsByRepo:     make(map[string]int),
+			Excluded:          e.GitHub.Excluded,
+		},
+	}
+
+	for _, repo := range e.GitHub.Commits {
+		data.GitHub.CommitsByRepo[repo.Repo] = repo.Commits
+	}
+	for _, commit := range e.GitHub.CommitHeadlines {
+		data.GitHub.Commits = append(data.GitHub.Commits, GitHubCommit(commit))
+	}
+	for _, pr := range e.GitHub.PullRequests {
+		data.GitHub.PullRequestsCreated = append(data.GitHub.PullRequestsCreated, pr.pullRequest())
+	}
+	for _, pr := range e.GitHub.Reviews {
+		data.GitHub.PullRequestsReviewed = append(data.GitHub.PullRequestsReviewed, pr.pullRequest())
+	}
+	for _, issue := range e.GitHub.Issues {
+		owner, name := splitRepo(issue.Repo)
+		data.GitHub.IssuesCreated = append(data.GitHub.IssuesCreated, GitHubIssue{
+			Title:      issue.Title,
+			URL:        issue.URL,
+			Number:     issue.Number,
+			RepoName:   name,
+			RepoOwner:  owner,
+			OccurredAt: issue.OccurredAt,
+			Notes:      issue.Notes,
+		})
+	}
+
+	for _, issue := range e.Issues {
+		data.Issues = append(data.Issues, IssueWithNotes{Details: issue.details(), UserNotes: issue.Notes, TimeSpent: issue.timeSpent()})
+	}
+	for _, issue := range e.NotWorked {
+		data.NotWorked = append(data.NotWorked, issue.details())
+	}
+
+	for _, entry := range e.Entries {
+		data.Entries = append(data.Entries, entry.entry())
+	}
+
+	for _, item := range e.Plan {
+		data.Plan = append(data.Plan, PlanItem(item))
+	}
+	for _, blocker := range e.Blockers {
+		data.Blockers = append(data.Blockers, Blocker(blocker))
+	}
+
+	for _, source := range e.Sources {
+		data.Sources = append(data.Sources, SourceReport{Name: source.Name, Status: source.Status, Error: source.Error, Items: source.Items})
+	}
+	for _, status := range e.Unavailable {
+		data.Unavailable = append(data.Unavailable, SourceStatus{Source: status.Source, Reason: status.Reason})
+	}
+
+	if e.ActiveTime != nil {
+		data.ActiveTime = e.ActiveTime.activeTime()
+	}
+
+	for _, event := range e.Timeline {
+		data.Timeline = append(data.Timeline, TimelineEvent(event))
+	}
+
+	return data
+}
+
+func (a ExportActiveTime) activeTime() ActiveTime {
+	active := ActiveTime{Total: time.Duration(a.Total) * time.Minute}
+	for _, repo := range a.Repos {
+		active.Repos = append(active.Repos, TimeEstimate{Name: repo.Name, Spent: time.Duration(repo.Minutes) * time.Minute})
+	}
+	for _, issue := range a.Issues {
+		active.Issues = append(active.Issues, TimeEstimate{Name: issue.Name, Spent: time.Duration(issue.Minutes) * time.Minute})
+	}
+	return active
+}
+
+func (pr ExportPullRequest) pullRequest() GitHubPullRequest {
+	owner, name := splitRepo(pr.Repo)
+	return GitHubPullRequest{
+		Title:      pr.Title,
+		URL:        pr.URL,
+		Number:     pr.Number,
+		State:      pr.State,
+		RepoName:   name,
+		RepoOwner:  owner,
+		OccurredAt: pr.OccurredAt,
+		Notes:      pr.Notes,
+	}
+}
+
+func (issue ExportIssue) details() LinearIssueDetails {
+	details := LinearIssueDetails{
+		ID:            issue.ID,
+		Title:         issue.Title,
+		URL:           issue.URL,
+		Identifier:    issue.Identifier,
+		PriorityLabel: issue.Priority,
+		UpdatedAt:     issue.UpdatedAt,
+		Description:   issue.Description,
+	}
+	details.State.Name = issue.State
+	details.Project.Name = issue.Project
+	details.State.Type = issue.StateType
+	for _, label := range issue.Labels {
+		details.Labels.Nodes = append(details.Labels.Nodes, struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		}{Name: label})
+	}
+	return details
+}
+
+func (issue ExportIssue) timeSpent() time.Duration {
+	return time.Duration(issue.Minutes) * time.Minute
+}
+
+func (entry ExportEntry) entry() FreeFormEntry {
+	return FreeFormEntry{Text: entry.Text, Category: entry.Category, At: entry.At}
+}
+
+func splitRepo(repo string) (owner string, name string) {
+	owner, name, found := strings.Cut(repo, "/")
+	if !found {
+		return "", repo
+	}
+	return owner, name
+}
+
+// ParseDay reads a day given on the command line: YYYY-MM-DD, "today" or "yesterday"
+func ParseDay(value string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(value), now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid day %q (expected YYYY-MM-DD, today or yesterday)", value)
+	}
+	return day, nil
+}
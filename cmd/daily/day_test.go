@@ -0,0 +1,129 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the data directory honours config, XDG_DATA_HOME and the home default
+func Test_DataDir(t *testing.T) {
+	config := viper.New()
+	config.Set("data.dir", "/tmp/crab-data")
+	dir, err := DataDir(config)
+	require.NoError(t, err)
+	assert.Equal(t, "/tmp/crab-data", dir)
+
+	t.Setenv("XDG_DATA_HOME", "/tmp/xdg")
+	dir, err = DataDir(viper.New())
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/tmp/xdg", "mastercrab"), dir)
+}
+
+// Test a day's record is saved to the history and read back
+func Test_DayRecord_RoundTrip(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	date := time.Date(2025, 10, 22, 12, 0, 0, 0, time.Local)
+	record := NewDailyExport(sampleExportData())
+
+	// Act
+	missing, err := LoadDayRecord(dataDir, date)
+	require.NoError(t, err)
+	require.NoError(t, RecordHistory(dataDir, date, record))
+	loaded, err := LoadDayRecord(dataDir, date)
+
+	// Assert
+	assert.Nil(t, missing)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, record.Issues, loaded.Issues)
+	assert.NoDirExists(t, filepath.Join(dataDir, "days"), "the history is the only store")
+}
+
+// Test an export converts back into the same summary
+func Test_DailyExport_SummaryData(t *testing.T) {
+	// Arrange
+	data := sampleExportData()
+	data.Issues[0].Details.ID = "issue-1"
+	data.NotWorked = []LinearIssueDetails{{ID: "issue-2", Identifier: "TEST-2"}}
+
+	// Act
+	restored := NewDailyExport(data).SummaryData()
+
+	// Assert
+	assert.Equal(t, NewDailyExport(data), NewDailyExport(restored))
+	assert.Equal(t, "testorg", restored.GitHub.PullRequestsCreated[0].RepoOwner)
+	assert.Equal(t, "backend", restored.Issues[0].Details.Labels.Nodes[0].Name)
+	assert.Equal(t, map[string]string{"issue-1": data.Issues[0].UserNotes, "issue-2": ""}, NewDailyExport(data).AnsweredIssues())
+}
+
+// Test a second run merges into the first without duplicates and renders both
+func Test_MergeExports_SameDay(t *testing.T) {
+	// Arrange
+	lunch := sampleExportData()
+	lunch.NotWorked = []LinearIssueDetails{{ID: "issue-2", Identifier: "TEST-2"}}
+	evening := sampleExportData()
+	evening.Issues = append(evening.Issues, IssueWithNotes{
+		Details:   LinearIssueDetails{ID: "issue-2", Identifier: "TEST-2", Title: "Billing", URL: "https://linear.app/test/issue/TEST-2"},
+		UserNotes: "Picked it up after all",
+	})
+	evening.NotWorked = nil
+
+	// Act
+	merged := MergeExports(NewDailyExport(lunch), NewDailyExport(evening)).SummaryData()
+
+	// Assert
+	require.Len(t, merged.Issues, 2)
+	assert.Empty(t, merged.NotWorked)
+	assert.Len(t, merged.GitHub.PullRequestsCreated, 1)
+
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, WriteSummaryOutput(merged, FormatMarkdown, "", path, ExistingOverwrite))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [TEST-2: Billing](https://linear.app/test/issue/TEST-2)\n  - Picked it up after all\n")
+}
+
+// Test appending a second run of the day only adds what the first one did not have
+func Test_NewSince_SameDay(t *testing.T) {
+	// Arrange
+	lunch := sampleSummaryData()
+	evening := sampleSummaryData()
+	evening.GitHub.TotalCommits = 7
+	evening.GitHub.CommitsByRepo = map[string]int{"testorg/mastercrab": 7}
+	evening.Issues[0].UserNotes = "Added token refresh\nFixed the review comments"
+	evening.Issues = append(evening.Issues, IssueWithNotes{
+		Details: LinearIssueDetails{Identifier: "TEST-2", Title: "Billing", URL: "https://linear.app/test/issue/TEST-2"},
+	})
+	previous := NewDailyExport(lunch)
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, WriteSummaryOutput(lunch, FormatMarkdown, "", path, ExistingOverwrite))
+
+	// Act
+	fresh := NewSince(previous, MergeExports(previous, NewDailyExport(evening))).SummaryData()
+	err := WriteSummaryOutput(fresh, FormatMarkdown, "", path, ExistingAppend)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Empty(t, fresh.GitHub.PullRequestsCreated)
+	assert.Empty(t, fresh.GitHub.PullRequestsReviewed)
+	assert.Equal(t, map[string]int{"testorg/mastercrab": 2}, fresh.GitHub.CommitsByRepo)
+	require.Len(t, fresh.Issues, 2)
+	assert.Equal(t, "Fixed the review comments", fresh.Issues[0].UserNotes)
+	assert.Equal(t, "TEST-2", fresh.Issues[1].Details.Identifier)
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, 1, strings.Count(string(content), "pull/10)"), "pull requests are not appended twice")
+	assert.Equal(t, 1, strings.Count(string(content), "Added token refresh"), "notes are not appended twice")
+	assert.Equal(t, 1, strings.Count(string(content), "Fixed the review comments"))
+
+	again := NewSince(MergeExports(previous, NewDailyExport(evening)), MergeExports(previous, NewDailyExport(evening))).SummaryData()
+	assert.True(t, again.Empty(), "a rerun with nothing new appends nothing")
+}
@@ -0,0 +1,185 @@
+package daily
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// editorCommentPrefix starts the lines of the notes file that are not kept
+const editorCommentPrefix = "#"
+
+// NotesEditor returns the editor command from $VISUAL or $EDITOR, or "" when neither is set
+func NotesEditor() string {
+	if editor := strings.TrimSpace(os.Getenv("VISUAL")); editor != "" {
+		return editor
+	}
+	return strings.TrimSpace(os.Getenv("EDITOR"))
+}
+
+// ComposeNotesInEditor opens the user's editor on a temporary file pre-filled with a
+// commented header describing the issue, and returns everything that is not a comment.
+// previousNotes, when set, are pre-filled below the header so they can be edited.
+func ComposeNotesInEditor(issue LinearIssueDetails, previousNotes string) (string, error) {
+	return composeNotes(issueEditorSubject(issue), previousNotes)
+}
+
+// ComposeItemNotesInEditor is ComposeNotesInEditor for a GitHub item
+func ComposeItemNotesInEditor(item ActivityItem, previousNotes string) (string, error) {
+	return composeNotes(itemEditorSubject(item), previousNotes)
+}
+
+// editorSubject is what a notes file is about
+type editorSubject struct {
+	// name is part of the file name, so editors can tell notes files apart
+	name string
+	// header lines describe the subject at the top of the file
+	header []string
+}
+
+func issueEditorSubject(issue LinearIssueDetails) editorSubject {
+	subject := editorSubject{
+		name: issue.Identifier,
+		header: []string{
+			fmt.Sprintf("%s: %s", issue.Identifier, issue.Title),
+			issue.URL,
+			fmt.Sprintf("State: %s | Priority: %s", issue.State.Name, issue.PriorityLabel),
+		},
+	}
+
+	comments := issue.Comments.Nodes
+	if len(comments) > 3 {
+		comments = comments[len(comments)-3:]
+	}
+	if len(comments) > 0 {
+		subject.header = append(subject.header, "", "Recent comments:")
+		for _, c := range comments {
+			subject.header = append(subject.header, fmt.Sprintf("  %s (%s):", c.User.Name, c.UpdatedAt))
+			for _, line := range strings.Split(strings.TrimSpace(c.Body), "\n") {
+				subject.header = append(subject.header, "    "+line)
+			}
+		}
+	}
+	return subject
+}
+
+func itemEditorSubject(item ActivityItem) editorSubject {
+	return editorSubject{
+		name: item.Identifier,
+		header: []string{
+			fmt.Sprintf("%s: %s", item.Identifier, item.Title),
+			item.URL,
+			fmt.Sprintf("Repository: %s | Occurred at: %s", item.Repo, item.OccurredAt),
+		},
+	}
+}
+
+func composeNotes(subject editorSubject, previousNotes string) (string, error) {
+	command, path, err := notesEditorCommand(subject, previousNotes)
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(path)
+
+	command.Stdin = os.Stdin
+	command.Stdout = console
+	command.Stderr = os.Stderr
+	if err := command.Run(); err != nil {
+		return "", fmt.Errorf("editor %q failed: %w", NotesEditor(), err)
+	}
+	return readEditedNotes(path)
+}
+
+// notesEditorCommand writes the notes file and returns the command that edits it.
+// The caller runs the command, reads the notes back with readEditedNotes and removes the file.
+func notesEditorCommand(subject editorSubject, previousNotes string) (*exec.Cmd, string, error) {
+	editor := NotesEditor()
+	if editor == "" {
+		return nil, "", fmt.Errorf("neither $VISUAL nor $EDITOR is set")
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("crab-notes-%s-*.md", sanitizeFilename(subject.name)))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create notes file: %w", err)
+	}
+
+	_, err = file.WriteString(editorNotesTemplate(subject, previousNotes))
+	closeErr := file.Close()
+	if err != nil || closeErr != nil {
+		os.Remove(file.Name())
+		return nil, "", fmt.Errorf("failed to write notes file: %w", firstError(err, closeErr))
+	}
+
+	// Editors such as "code --wait" come with arguments
+	args := strings.Fields(editor)
+	return exec.Command(args[0], append(args[1:], file.Name())...), file.Name(), nil
+}
+
+// readEditedNotes reads the notes saved in the editor, without the comment lines
+func readEditedNotes(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read notes file: %w", err)
+	}
+	return StripEditorComments(string(content)), nil
+}
+
+// editorNotesTemplate is the initial content of the notes file
+func editorNotesTemplate(subject editorSubject, previousNotes string) string {
+	var header strings.Builder
+	comment := func(line string) {
+		header.WriteString(strings.TrimRight(editorCommentPrefix+" "+line, " ") + "\n")
+	}
+
+	for _, line := range subject.header {
+		comment(line)
+	}
+
+	comment("")
+	comment("Describe what you did below. Lines starting with '" + editorCommentPrefix + "' are")
+	comment("ignored, except inside ``` code blocks. Save and close the editor")
+	comment("when you are done.")
+	header.WriteString("\n")
+
+	if previousNotes != "" {
+		header.WriteString(previousNotes + "\n")
+	}
+	return header.String()
+}
+
+// StripEditorComments drops comment lines, keeping those inside fenced code blocks,
+// and trims blank lines around the notes
+func StripEditorComments(content string) string {
+	var kept []string
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, editorCommentPrefix) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Trim(strings.Join(kept, "\n"), "\n")
+}
+
+func sanitizeFilename(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == ' ' || r == '*' {
+			return '-'
+		}
+		return r
+	}, name)
+}
+
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
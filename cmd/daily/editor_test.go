@@ -0,0 +1,69 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleEditorIssue() LinearIssueDetails {
+	issue := LinearIssueDetails{Identifier: "TEST-123", Title: "Implement auth", URL: "https://linear.app/test/issue/TEST-123"}
+	issue.State.Name = "In Progress"
+	issue.PriorityLabel = "High"
+	issue.Comments.Nodes = append(issue.Comments.Nodes, struct {
+		ID        string `json:"id"`
+		Body      string `json:"body"`
+		CreatedAt string `json:"createdAt"`
+		UpdatedAt string `json:"updatedAt"`
+		User      struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}{Body: "Started implementation\nof the flow", UpdatedAt: "2025-10-22T10:00:00Z"})
+	issue.Comments.Nodes[0].User.Name = "Alice Developer"
+	return issue
+}
+
+// Test comment lines are dropped but code blocks are kept intact
+func Test_StripEditorComments(t *testing.T) {
+	content := "# TEST-123: header\n#\n\nFixed the retry loop\n\n```go\n# not a comment\nretry()\n```\n# trailing comment\n"
+
+	assert.Equal(t, "Fixed the retry loop\n\n```go\n# not a comment\nretry()\n```", StripEditorComments(content))
+}
+
+// Test the notes file header describes the issue and its recent comments
+func Test_EditorNotesTemplate(t *testing.T) {
+	content := editorNotesTemplate(issueEditorSubject(sampleEditorIssue()), "Earlier notes")
+
+	assert.Contains(t, content, "# TEST-123: Implement auth\n# https://linear.app/test/issue/TEST-123\n")
+	assert.Contains(t, content, "#   Alice Developer (2025-10-22T10:00:00Z):\n#     Started implementation\n#     of the flow\n")
+	assert.Equal(t, "Earlier notes", StripEditorComments(content))
+}
+
+// Test notes are read back from the file the editor saved
+func Test_ComposeNotesInEditor(t *testing.T) {
+	// Arrange - a fake editor that appends a paragraph to the file
+	script := filepath.Join(t.TempDir(), "fake-editor.sh")
+	require.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf 'Paired on the fix\\n\\nShipped it\\n' >> \"$1\"\n"), 0o755))
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", script)
+
+	// Act
+	notes, err := ComposeNotesInEditor(sampleEditorIssue(), "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "Paired on the fix\n\nShipped it", notes)
+}
+
+// Test a missing editor is reported so callers can fall back to inline notes
+func Test_ComposeNotesInEditor_NoEditor(t *testing.T) {
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "")
+
+	_, err := ComposeNotesInEditor(sampleEditorIssue(), "")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package daily
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// PromptForEntries asks for work that is not tied to an issue or PR, each with an optional
+// category, until an empty line. Every entry is handed to record as soon as it is complete.
+func PromptForEntries(input io.Reader, record func(FreeFormEntry)) {
+	reader := bufio.NewReader(input)
+
+	fmt.Fprintln(console, "\n❓ Anything else? Meetings, interviews, incident calls, mentoring...")
+	fmt.Fprintln(console, "   (Press Enter on an empty line to finish)")
+
+	for {
+		fmt.Fprint(console, "▶ ")
+		text, err := reader.ReadString('\n')
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+
+		fmt.Fprint(console, "🏷️  Category (optional, e.g. meeting, interview, incident): ")
+		category, categoryErr := reader.ReadString('\n')
+
+		record(FreeFormEntry{Text: text, Category: strings.TrimSpace(category), At: time.Now()})
+		fmt.Fprintln(console, "✅ Added!")
+
+		if err != nil || categoryErr != nil {
+			return
+		}
+	}
+}
+
+// promptForEntries runs PromptForEntries on stdin, saving each entry to the session, and
+// returns as soon as ctx is cancelled
+func promptForEntries(ctx context.Context, session *Session) {
+	PromptForEntries(stdin.WithContext(ctx), func(entry FreeFormEntry) {
+		if err := session.RecordEntry(entry); err != nil {
+			fmt.Fprintf(console, "⚠️  Your entry could not be saved for resuming: %s\n", err)
+		}
+	})
+}
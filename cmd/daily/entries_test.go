@@ -0,0 +1,58 @@
+package daily
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test entries are collected with their optional category until an empty line
+func Test_PromptForEntries(t *testing.T) {
+	// Arrange
+	input := strings.NewReader("Sprint planning\nmeeting\nHelped Sam with the deploy\n\n\nnot read\n")
+	var entries []FreeFormEntry
+
+	// Act
+	PromptForEntries(input, func(entry FreeFormEntry) { entries = append(entries, entry) })
+
+	// Assert
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Sprint planning", entries[0].Text)
+	assert.Equal(t, "meeting", entries[0].Category)
+	assert.Equal(t, "Helped Sam with the deploy", entries[1].Text)
+	assert.Empty(t, entries[1].Category)
+	assert.False(t, entries[0].At.IsZero())
+}
+
+// Test entries are kept in the session, the summary and the export
+func Test_FreeFormEntries_SummaryAndExport(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	session := NewSession(dataDir, time.Now(), Window{})
+	at := time.Date(2025, 10, 22, 14, 0, 0, 0, time.UTC)
+	require.NoError(t, session.RecordEntry(FreeFormEntry{Text: "Incident call on the payment outage", Category: "incident", At: at}))
+	resumed, err := LoadSession(dataDir)
+	require.NoError(t, err)
+
+	data := sampleSummaryData()
+	data.Entries = resumed.FreeFormEntries()
+
+	// Act
+	var markdown, export bytes.Buffer
+	require.NoError(t, RenderSummary(&markdown, data, ""))
+	require.NoError(t, WriteFormatted(&export, data, FormatJSON, ""))
+
+	// Assert
+	assert.Contains(t, markdown.String(), "## Other Work\n\n- **incident:** Incident call on the payment outage\n")
+
+	var decoded DailyExport
+	require.NoError(t, json.Unmarshal(export.Bytes(), &decoded))
+	require.Len(t, decoded.Entries, 1)
+	assert.Equal(t, ExportEntry{Text: "Incident call on the payment outage", Category: "incident", At: at}, decoded.Entries[0])
+	assert.Equal(t, data.Entries, decoded.SummaryData().Entries)
+}
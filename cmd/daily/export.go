@@ -0,0 +1,380 @@
+package daily
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ExportSchemaVersion is bumped whenever a field of the export schema is renamed or removed.
+// Adding fields does not change the version.
+const ExportSchemaVersion = 1
+
+// Output formats supported by --format
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatStandup  = "standup"
+)
+
+// DailyExport is the stable, versioned schema of a day's data for JSON and YAML output
+type DailyExport struct {
+	SchemaVersion int                 `json:"schemaVersion" yaml:"schemaVersion"`
+	GeneratedAt   time.Time           `json:"generatedAt" yaml:"generatedAt"`
+	Window        ExportWindow        `json:"window" yaml:"window"`
+	GitHub        ExportGitHub        `json:"github" yaml:"github"`
+	Issues        []ExportIssue       `json:"issues" yaml:"issues"`
+	Sources       []ExportSource      `json:"sources" yaml:"sources"`
+	Unavailable   []ExportUnavailable `json:"unavailable,omitempty" yaml:"unavailable,omitempty"`
+	NotWorked     []ExportIssue       `json:"notWorked,omitempty" yaml:"notWorked,omitempty"`
+	Entries       []ExportEntry       `json:"entries,omitempty" yaml:"entries,omitempty"`
+	Plan          []ExportPlanItem    `json:"plan,omitempty" yaml:"plan,omitempty"`
+	Blockers      []ExportBlocker     `json:"blockers,omitempty" yaml:"blockers,omitempty"`
+	// Days is the number of days a report covers, 0 for a single day
+	Days int `json:"days,omitempty" yaml:"days,omitempty"`
+	// ActiveTime is the active time estimated from activity timestamps
+	ActiveTime *ExportActiveTime `json:"activeTime,omitempty" yaml:"activeTime,omitempty"`
+	// Timeline is the day's activity in chronological order, with "summary.timeline: true"
+	Timeline []ExportTimelineEvent `json:"timeline,omitempty" yaml:"timeline,omitempty"`
+}
+
+// ExportTimelineEvent is something that happened at a known time during the day
+type ExportTimelineEvent struct {
+	At     time.Time `json:"at" yaml:"at"`
+	Source string    `json:"source" yaml:"source"`
+	Kind   string    `json:"kind" yaml:"kind"`
+	Title  string    `json:"title" yaml:"title"`
+	Detail string    `json:"detail,omitempty" yaml:"detail,omitempty"`
+	URL    string    `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// ExportActiveTime is the estimated active time, in minutes
+type ExportActiveTime struct {
+	Total  int              `json:"total" yaml:"total"`
+	Repos  []ExportEstimate `json:"repos,omitempty" yaml:"repos,omitempty"`
+	Issues []ExportEstimate `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// ExportEstimate is the estimated active time for a repository or a Linear issue, in minutes
+type ExportEstimate struct {
+	Name    string `json:"name" yaml:"name"`
+	Minutes int    `json:"minutes" yaml:"minutes"`
+}
+
+// ExportWindow is the period a run covers
+type ExportWindow struct {
+	Since time.Time `json:"since" yaml:"since"`
+	Until time.Time `json:"until" yaml:"until"`
+}
+
+// ExportGitHub is the viewer's GitHub activity
+type ExportGitHub struct {
+	Username string              `json:"username" yaml:"username"`
+	Totals   ExportGitHubTotals  `json:"totals" yaml:"totals"`
+	Commits  []ExportRepoCommits `json:"commits" yaml:"commits"`
+	// CommitHeadlines are the commits pushed to default branches
+	CommitHeadlines []ExportCommit      `json:"commitHeadlines,omitempty" yaml:"commitHeadlines,omitempty"`
+	PullRequests    []ExportPullRequest `json:"pullRequests" yaml:"pullRequests"`
+	Reviews         []ExportPullRequest `json:"reviews" yaml:"reviews"`
+	Issues          []ExportGitHubIssue `json:"issues" yaml:"issues"`
+	// Excluded are the URLs of items left out of the summary
+	Excluded []string `json:"excluded,omitempty" yaml:"excluded,omitempty"`
+}
+
+// ExportGitHubTotals are the contribution counts reported by GitHub
+type ExportGitHubTotals struct {
+	Commits      int `json:"commits" yaml:"commits"`
+	PullRequests int `json:"pullRequests" yaml:"pullRequests"`
+	Reviews      int `json:"reviews" yaml:"reviews"`
+	Issues       int `json:"issues" yaml:"issues"`
+}
+
+// ExportRepoCommits is the number of commits made to a repository
+type ExportRepoCommits struct {
+	Repo    string `json:"repo" yaml:"repo"`
+	Commits int    `json:"commits" yaml:"commits"`
+}
+
+// ExportCommit is a commit pushed by the viewer
+type ExportCommit struct {
+	Repo        string `json:"repo" yaml:"repo"`
+	Headline    string `json:"headline" yaml:"headline"`
+	URL         string `json:"url" yaml:"url"`
+	CommittedAt string `json:"committedAt" yaml:"committedAt"`
+}
+
+// ExportPullRequest is a pull request created or reviewed by the viewer
+type ExportPullRequest struct {
+	Repo       string `json:"repo" yaml:"repo"`
+	Number     int    `json:"number" yaml:"number"`
+	Title      string `json:"title" yaml:"title"`
+	URL        string `json:"url" yaml:"url"`
+	State      string `json:"state,omitempty" yaml:"state,omitempty"`
+	OccurredAt string `json:"occurredAt" yaml:"occurredAt"`
+	Notes      string `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// ExportGitHubIssue is a GitHub issue opened by the viewer
+type ExportGitHubIssue struct {
+	Repo       string `json:"repo" yaml:"repo"`
+	Number     int    `json:"number" yaml:"number"`
+	Title      string `json:"title" yaml:"title"`
+	URL        string `json:"url" yaml:"url"`
+	OccurredAt string `json:"occurredAt" yaml:"occurredAt"`
+	Notes      string `json:"notes,omitempty" yaml:"notes,omitempty"`
+}
+
+// ExportIssue is a Linear issue the viewer worked on, with their notes
+type ExportIssue struct {
+	ID         string   `json:"id" yaml:"id"`
+	Identifier string   `json:"identifier" yaml:"identifier"`
+	Title      string   `json:"title" yaml:"title"`
+	URL        string   `json:"url" yaml:"url"`
+	State      string   `json:"state" yaml:"state"`
+	StateType  string   `json:"stateType" yaml:"stateType"`
+	Priority   string   `json:"priority" yaml:"priority"`
+	Labels     []string `json:"labels" yaml:"labels"`
+	UpdatedAt  string   `json:"updatedAt" yaml:"updatedAt"`
+	Notes      string   `json:"notes" yaml:"notes"`
+	// Description is kept so past days can be searched
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	// Project is the Linear project of the issue, if any
+	Project string `json:"project,omitempty" yaml:"project,omitempty"`
+	// Minutes is the time spent on the issue, 0 when it was not asked
+	Minutes int `json:"minutes,omitempty" yaml:"minutes,omitempty"`
+}
+
+// ExportEntry is work that is not tied to an issue or pull request
+type ExportEntry struct {
+	Text     string    `json:"text" yaml:"text"`
+	Category string    `json:"category,omitempty" yaml:"category,omitempty"`
+	At       time.Time `json:"at" yaml:"at"`
+}
+
+// ExportPlanItem is something planned for the next day
+type ExportPlanItem struct {
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+	Title      string `json:"title" yaml:"title"`
+	URL        string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// ExportBlocker is something in the way, with the Linear issue it blocks if any
+type ExportBlocker struct {
+	Identifier string `json:"identifier,omitempty" yaml:"identifier,omitempty"`
+	Title      string `json:"title,omitempty" yaml:"title,omitempty"`
+	URL        string `json:"url,omitempty" yaml:"url,omitempty"`
+	Reason     string `json:"reason" yaml:"reason"`
+}
+
+// ExportSource is the status of a source for the run
+type ExportSource struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+	Items  int    `json:"items" yaml:"items"`
+}
+
+// ExportUnavailable is something that could not be fetched, as listed in the summary
+type ExportUnavailable struct {
+	Source string `json:"source" yaml:"source"`
+	Reason string `json:"reason" yaml:"reason"`
+}
+
+// NewDailyExport converts summary data into the export schema
+func NewDailyExport(data SummaryData) DailyExport {
+	export := DailyExport{
+		SchemaVersion: ExportSchemaVersion,
+		GeneratedAt:   data.Date,
+		Window:        ExportWindow{Since: data.Window.Since, Until: data.Window.Until},
+		GitHub: ExportGitHub{
+			Username: data.GitHub.Username,
+			Totals: ExportGitHubTotals{
+				Commits:      data.GitHub.TotalCommits,
+				PullRequests: data.GitHub.TotalPullRequests,
+				Reviews:      data.GitHub.TotalReviews,
+				Issues:       data.GitHub.TotalIssues,
+			},
+			Commits:      []ExportRepoCommits{},
+			PullRequests: exportPullRequests(data.GitHub.PullRequestsCreated),
+			Reviews:      exportPullRequests(data.GitHub.PullRequestsReviewed),
+			Issues:       []ExportGitHubIssue{},
+			Excluded:     data.GitHub.Excluded,
+		},
+		Issues:  []ExportIssue{},
+		Sources: []ExportSource{},
+		Days:    data.Days,
+	}
+
+	for _, repo := range sortedKeys(data.GitHub.CommitsByRepo) {
+		export.GitHub.Commits = append(export.GitHub.Commits, ExportRepoCommits{Repo: repo, Commits: data.GitHub.CommitsByRepo[repo]})
+	}
+
+	for _, commit := range data.GitHub.Commits {
+		export.GitHub.CommitHeadlines = append(export.GitHub.CommitHeadlines, ExportCommit(commit))
+	}
+
+	for _, issue := range data.GitHub.IssuesCreated {
+		export.GitHub.Issues = append(export.GitHub.Issues, ExportGitHubIssue{
+			Repo:       issue.RepoOwner + "/" + issue.RepoName,
+			Number:     issue.Number,
+			Title:      issue.Title,
+			URL:        issue.URL,
+			OccurredAt: issue.OccurredAt,
+			Notes:      issue.Notes,
+		})
+	}
+
+	for _, issueNote := range data.Issues {
+		issue := exportIssue(issueNote.Details, issueNote.UserNotes)
+		issue.Minutes = int(issueNote.TimeSpent / time.Minute)
+		export.Issues = append(export.Issues, issue)
+	}
+
+	for _, issue := range data.NotWorked {
+		export.NotWorked = append(export.NotWorked, exportIssue(issue, ""))
+	}
+
+	for _, entry := range data.Entries {
+		export.Entries = append(export.Entries, exportEntry(entry))
+	}
+
+	for _, item := range data.Plan {
+		export.Plan = append(export.Plan, ExportPlanItem(item))
+	}
+	for _, blocker := range data.Blockers {
+		export.Blockers = append(export.Blockers, ExportBlocker(blocker))
+	}
+
+	for _, report := range data.Sources {
+		export.Sources = append(export.Sources, ExportSource{
+			Name:   report.Name,
+			Status: report.Status,
+			Error:  report.Error,
+			Items:  report.Items,
+		})
+	}
+
+	for _, status := range data.Unavailable {
+		export.Unavailable = append(export.Unavailable, ExportUnavailable{Source: status.Source, Reason: status.Reason})
+	}
+
+	if !data.ActiveTime.Empty() {
+		export.ActiveTime = &ExportActiveTime{
+			Total:  int(data.ActiveTime.Total / time.Minute),
+			Repos:  exportEstimates(data.ActiveTime.Repos),
+			Issues: exportEstimates(data.ActiveTime.Issues),
+		}
+	}
+
+	for _, event := range data.Timeline {
+		export.Timeline = append(export.Timeline, ExportTimelineEvent(event))
+	}
+
+	return export
+}
+
+func exportEstimates(estimates []TimeEstimate) []ExportEstimate {
+	var exported []ExportEstimate
+	for _, estimate := range estimates {
+		exported = append(exported, ExportEstimate{Name: estimate.Name, Minutes: int(estimate.Spent / time.Minute)})
+	}
+	return exported
+}
+
+func exportIssue(issue LinearIssueDetails, notes string) ExportIssue {
+	labels := make([]string, len(issue.Labels.Nodes))
+	for i, label := range issue.Labels.Nodes {
+		labels[i] = label.Name
+	}
+
+	return ExportIssue{
+		ID:         issue.ID,
+		Identifier: issue.Identifier,
+		Title:      issue.Title,
+		URL:        issue.URL,
+		State:      issue.State.Name,
+		StateType:  issue.State.Type,
+		Priority:   issue.PriorityLabel,
+		Labels:     labels,
+		UpdatedAt:  issue.UpdatedAt,
+		Notes:      notes,
+
+		Description: issue.Description,
+		Project:     issue.Project.Name,
+	}
+}
+
+func exportEntry(entry FreeFormEntry) ExportEntry {
+	return ExportEntry{Text: entry.Text, Category: entry.Category, At: entry.At}
+}
+
+func exportPullRequests(pullRequests []GitHubPullRequest) []ExportPullRequest {
+	exported := []ExportPullRequest{}
+	for _, pr := range pullRequests {
+		exported = append(exported, ExportPullRequest{
+			Repo:       pr.RepoOwner + "/" + pr.RepoName,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			URL:        pr.URL,
+			State:      pr.State,
+			OccurredAt: pr.OccurredAt,
+			Notes:      pr.Notes,
+		})
+	}
+	return exported
+}
+
+// ParseFormat validates an output format name
+func ParseFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", "md", FormatMarkdown:
+		return FormatMarkdown, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	case "yml", FormatYAML:
+		return FormatYAML, nil
+	case FormatStandup:
+		return FormatStandup, nil
+	}
+	return "", fmt.Errorf("unknown format %q (expected markdown, json, yaml or standup)", format)
+}
+
+// FormatExtension is the file extension used for a format
+func FormatExtension(format string) string {
+	switch format {
+	case FormatJSON:
+		return "json"
+	case FormatYAML:
+		return "yaml"
+	}
+	return "md"
+}
+
+// WriteFormatted writes the summary data in the requested format.
+// templatePath is only used for markdown and standup.
+func WriteFormatted(w io.Writer, data SummaryData, format string, templatePath string) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(NewDailyExport(data)); err != nil {
+			return fmt.Errorf("failed to encode JSON: %w", err)
+		}
+		return nil
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(NewDailyExport(data)); err != nil {
+			return fmt.Errorf("failed to encode YAML: %w", err)
+		}
+		return encoder.Close()
+	case FormatStandup:
+		return RenderStandup(w, data, templatePath)
+	}
+	return RenderSummary(w, data, templatePath)
+}
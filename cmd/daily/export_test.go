@@ -0,0 +1,95 @@
+package daily
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func sampleExportData() SummaryData {
+	data := sampleSummaryData()
+	data.Window = Window{
+		Since: time.Date(2025, 10, 21, 18, 0, 0, 0, time.UTC),
+		Until: time.Date(2025, 10, 22, 18, 0, 0, 0, time.UTC),
+	}
+	data.Issues[0].Details.State.Name = "In Progress"
+	data.Issues[0].Details.Labels.Nodes = append(data.Issues[0].Details.Labels.Nodes, struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}{Name: "backend"})
+	data.Sources = []SourceReport{
+		{Name: "github", Status: SourceOK, Items: 3},
+		{Name: "linear", Status: SourceFailed, Error: "timed out"},
+	}
+	return data
+}
+
+// Test the export schema is versioned and carries every part of the run
+func Test_NewDailyExport(t *testing.T) {
+	// Act
+	export := NewDailyExport(sampleExportData())
+
+	// Assert
+	assert.Equal(t, ExportSchemaVersion, export.SchemaVersion)
+	assert.Equal(t, time.Date(2025, 10, 21, 18, 0, 0, 0, time.UTC), export.Window.Since)
+	assert.Equal(t, []ExportRepoCommits{{Repo: "testorg/mastercrab", Commits: 5}}, export.GitHub.Commits)
+	assert.Equal(t, "testorg/mastercrab", export.GitHub.PullRequests[0].Repo)
+	assert.Equal(t, 5, export.GitHub.Reviews[0].Number)
+	assert.Empty(t, export.GitHub.Issues)
+	require.Len(t, export.Issues, 1)
+	assert.Equal(t, "TEST-123", export.Issues[0].Identifier)
+	assert.Equal(t, []string{"backend"}, export.Issues[0].Labels)
+	assert.Contains(t, export.Issues[0].Notes, "Added token refresh")
+	assert.Equal(t, SourceFailed, export.Sources[1].Status)
+}
+
+// Test JSON output uses the documented field names
+func Test_WriteFormatted_JSON(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	err := WriteFormatted(&out, sampleExportData(), FormatJSON, "")
+
+	// Assert
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, float64(1), decoded["schemaVersion"])
+	assert.Contains(t, decoded, "window")
+	assert.Contains(t, decoded["github"], "pullRequests")
+	assert.Equal(t, "TEST-123", decoded["issues"].([]interface{})[0].(map[string]interface{})["identifier"])
+}
+
+// Test YAML output round-trips into the export schema
+func Test_WriteFormatted_YAML(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	err := WriteFormatted(&out, sampleExportData(), FormatYAML, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "schemaVersion: 1\n")
+	var decoded DailyExport
+	require.NoError(t, yaml.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, NewDailyExport(sampleExportData()), decoded)
+}
+
+// Test format names are validated
+func Test_ParseFormat(t *testing.T) {
+	for input, expected := range map[string]string{"": FormatMarkdown, "md": FormatMarkdown, "JSON": FormatJSON, "yml": FormatYAML, "standup": FormatStandup} {
+		format, err := ParseFormat(input)
+		require.NoError(t, err)
+		assert.Equal(t, expected, format)
+	}
+
+	_, err := ParseFormat("xml")
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultSourceTimeout bounds how long a single source may take when no timeout is configured
+const DefaultSourceTimeout = 30 * time.Second
+
+// SourceFetch is the outcome of fetching a single source
+type SourceFetch struct {
+	Source   ActivitySource
+	Result   SourceResult
+	Err      error
+	Duration time.Duration
+}
+
+// NewHTTPClient creates the HTTP client shared by every source.
+// The "http.timeout" config key caps any single request. With "http.record" every
+// exchange is saved to that directory, and with "http.replay" they are served from it.
+func NewHTTPClient(config *viper.Viper) *http.Client {
+	timeout := config.GetDuration("http.timeout")
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+	if dir := config.GetString("http.replay"); dir != "" {
+		client.Transport = NewReplayTransport(dir)
+	} else if dir := config.GetString("http.record"); dir != "" {
+		secrets := []string{config.GetString("linear.apiToken"), config.GetString("github.apiToken")}
+		client.Transport = NewRecordingTransport(dir, secrets, nil)
+	}
+	return client
+}
+
+// SourceTimeout returns the deadline for a source, read from "<name>.timeout"
+// and falling back to "fetch.timeout", then DefaultSourceTimeout
+func SourceTimeout(name string, config *viper.Viper) time.Duration {
+	if timeout := config.GetDuration(name + ".timeout"); timeout > 0 {
+		return timeout
+	}
+	if timeout := config.GetDuration("fetch.timeout"); timeout > 0 {
+		return timeout
+	}
+	return DefaultSourceTimeout
+}
+
+// FetchAll fetches every source concurrently, each under its own deadline.
+// progress, when set, is called with the names of the sources still pending each time one finishes.
+// Results are returned in the same order as sources.
+func FetchAll(ctx context.Context, client *http.Client, sources []ActivitySource, window Window, config *viper.Viper, progress func(done SourceFetch, pending []string)) []SourceFetch {
+	type completion struct {
+		index int
+		fetch SourceFetch
+	}
+
+	completions := make(chan completion)
+	for i, source := range sources {
+		go func(i int, source ActivitySource) {
+			sourceCtx, cancel := context.WithTimeout(ctx, SourceTimeout(source.Name(), config))
+			defer cancel()
+
+			started := time.Now()
+			result, err := source.Fetch(sourceCtx, client, window, config)
+			if err != nil && sourceCtx.Err() == context.DeadlineExceeded {
+				err = fmt.Errorf("timed out after %s: %w", SourceTimeout(source.Name(), config), err)
+			}
+
+			completions <- completion{index: i, fetch: SourceFetch{
+				Source:   source,
+				Result:   result,
+				Err:      err,
+				Duration: time.Since(started),
+			}}
+		}(i, source)
+	}
+
+	fetches := make([]SourceFetch, len(sources))
+	pending := make(map[int]bool, len(sources))
+	for i := range sources {
+		pending[i] = true
+	}
+
+	for range sources {
+		done := <-completions
+		fetches[done.index] = done.fetch
+		delete(pending, done.index)
+
+		if progress != nil {
+			var names []string
+			for i, source := range sources {
+				if pending[i] {
+					names = append(names, source.Name())
+				}
+			}
+			progress(done.fetch, names)
+		}
+	}
+
+	return fetches
+}
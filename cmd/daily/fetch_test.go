@@ -0,0 +1,114 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSource is an ActivitySource that answers after a delay
+type fakeSource struct {
+	name  string
+	delay time.Duration
+	err   error
+}
+
+func (f fakeSource) Name() string                 { return f.name }
+func (f fakeSource) Configured(*viper.Viper) bool { return true }
+
+func (f fakeSource) Fetch(ctx context.Context, _ *http.Client, _ Window, _ *viper.Viper) (SourceResult, error) {
+	select {
+	case <-time.After(f.delay):
+	case <-ctx.Done():
+		return SourceResult{}, ctx.Err()
+	}
+	if f.err != nil {
+		return SourceResult{}, f.err
+	}
+	return SourceResult{
+		Items:   []ActivityItem{{Source: f.name, Title: f.name + " item"}},
+		Summary: "1 item",
+	}, nil
+}
+
+// Test sources are fetched in parallel and results keep the source order
+func Test_FetchAll_ParallelAndOrdered(t *testing.T) {
+	// Arrange
+	sources := []ActivitySource{
+		fakeSource{name: "slow", delay: 100 * time.Millisecond},
+		fakeSource{name: "fast", delay: 10 * time.Millisecond},
+		fakeSource{name: "broken", delay: 50 * time.Millisecond, err: fmt.Errorf("boom")},
+	}
+	var finished []string
+	var pendingAfterFirst []string
+
+	// Act
+	started := time.Now()
+	fetches := FetchAll(context.Background(), &http.Client{}, sources, Window{}, viper.New(), func(done SourceFetch, pending []string) {
+		if len(finished) == 0 {
+			pendingAfterFirst = pending
+		}
+		finished = append(finished, done.Source.Name())
+	})
+
+	// Assert
+	assert.Less(t, time.Since(started), 250*time.Millisecond, "sources should be fetched concurrently")
+	require.Len(t, fetches, 3)
+	assert.Equal(t, "slow", fetches[0].Source.Name())
+	assert.Equal(t, "fast", fetches[1].Source.Name())
+	assert.NoError(t, fetches[0].Err)
+	assert.EqualError(t, fetches[2].Err, "boom")
+	assert.Equal(t, []string{"fast", "broken", "slow"}, finished)
+	assert.Equal(t, []string{"slow", "broken"}, pendingAfterFirst)
+}
+
+// Test a source that exceeds its deadline is reported as timed out
+func Test_FetchAll_SourceTimeout(t *testing.T) {
+	// Arrange
+	config := viper.New()
+	config.Set("hung.timeout", "20ms")
+	sources := []ActivitySource{
+		fakeSource{name: "hung", delay: time.Minute},
+		fakeSource{name: "ok", delay: time.Millisecond},
+	}
+
+	// Act
+	fetches := FetchAll(context.Background(), &http.Client{}, sources, Window{}, config, nil)
+
+	// Assert
+	require.Error(t, fetches[0].Err)
+	assert.Contains(t, fetches[0].Err.Error(), "timed out after 20ms")
+	assert.ErrorIs(t, fetches[0].Err, context.DeadlineExceeded)
+	assert.NoError(t, fetches[1].Err)
+}
+
+// Test cancelling the parent context stops every pending source
+func Test_FetchAll_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	fetches := FetchAll(ctx, &http.Client{}, []ActivitySource{fakeSource{name: "hung", delay: time.Minute}}, Window{}, viper.New(), nil)
+
+	// Assert
+	assert.ErrorIs(t, fetches[0].Err, context.Canceled)
+}
+
+// Test per-source timeouts fall back to the global and default values
+func Test_SourceTimeout(t *testing.T) {
+	config := viper.New()
+	assert.Equal(t, DefaultSourceTimeout, SourceTimeout("github", config))
+
+	config.Set("fetch.timeout", "10s")
+	assert.Equal(t, 10*time.Second, SourceTimeout("github", config))
+
+	config.Set("github.timeout", "5s")
+	assert.Equal(t, 5*time.Second, SourceTimeout("github", config))
+}
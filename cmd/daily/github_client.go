@@ -2,6 +2,7 @@ package daily
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,6 +12,9 @@ import (
 	"github.com/spf13/viper"
 )
 
+// githubGraphQLURL is GitHub's GraphQL endpoint
+var githubGraphQLURL = "https://api.github.com/graphql"
+
 // GitHubRequest represents a GraphQL request to GitHub's API
 type GitHubRequest struct {
 	Query     string                 `json:"query"`
@@ -100,12 +104,16 @@ type GitHubResponse struct {
 
 // GitHubActivity represents aggregated GitHub activity for the viewer
 type GitHubActivity struct {
-	Username             string
-	TotalCommits         int
-	TotalIssues          int
-	TotalPullRequests    int
-	TotalReviews         int
-	CommitsByRepo        map[string]int
+	Username          string
+	TotalCommits      int
+	TotalIssues       int
+	TotalPullRequests int
+	TotalReviews      int
+	CommitsByRepo     map[string]int
+	// Commits are the headlines of the commits pushed to default branches
+	Commits []GitHubCommit
+	// Excluded are the URLs of items the user left out of the summary
+	Excluded             []string
 	IssuesCreated        []GitHubIssue
 	PullRequestsCreated  []GitHubPullRequest
 	PullRequestsReviewed []GitHubPullRequest
@@ -118,6 +126,8 @@ type GitHubIssue struct {
 	RepoName   string
 	RepoOwner  string
 	OccurredAt string
+	// Notes are the user's notes about the issue
+	Notes string
 }
 
 type GitHubPullRequest struct {
@@ -128,13 +138,20 @@ type GitHubPullRequest struct {
 	RepoName   string
 	RepoOwner  string
 	OccurredAt string
+	// Notes are the user's notes about the pull request or the review
+	Notes string
+	// The size of the change and when it was merged, only fetched for long periods
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+	MergedAt     string
 }
 
 // GetViewerActivity fetches GitHub activity for the authenticated user within a time period
-func GetViewerActivity(client *http.Client, since time.Time, until time.Time, config *viper.Viper) (GitHubActivity, error) {
+func GetViewerActivity(ctx context.Context, client *http.Client, since time.Time, until time.Time, config *viper.Viper) (GitHubActivity, error) {
 	// Get required config values
 	githubToken := config.GetString("github.apiToken")
-	baseURL := "https://api.github.com/graphql"
+	baseURL := githubGraphQLURL
 
 	// Validate required config
 	if githubToken == "" {
@@ -234,7 +251,7 @@ func GetViewerActivity(client *http.Client, since time.Time, until time.Time, co
 	}
 
 	// Create HTTP request
-	request, err := http.NewRequest("POST", baseURL, bytes.NewBuffer(jsonValue))
+	request, err := http.NewRequestWithContext(ctx, "POST", baseURL, bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return GitHubActivity{}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -324,3 +341,160 @@ func GetViewerActivity(client *http.Client, since time.Time, until time.Time, co
 
 	return activity, nil
 }
+
+// GitHubSource exposes GitHub contributions as an ActivitySource
+type GitHubSource struct{}
+
+func init() {
+	RegisterSource(GitHubSource{})
+}
+
+// Name returns the config key of the source
+func (GitHubSource) Name() string {
+	return "github"
+}
+
+// Configured reports whether a GitHub token is available
+func (GitHubSource) Configured(config *viper.Viper) bool {
+	return config.GetString("github.apiToken") != ""
+}
+
+// Fetch retrieves the viewer's GitHub contributions and normalises them into items
+func (GitHubSource) Fetch(ctx context.Context, client *http.Client, window Window, config *viper.Viper) (SourceResult, error) {
+	activity, err := GetViewerActivity(ctx, client, window.Since, window.Until, config)
+	if err != nil {
+		return SourceResult{}, err
+	}
+
+	// Headlines only make the day searchable, the counts above stand without them
+	if commits, err := GetViewerCommits(ctx, client, window.Since, window.Until, config); err == nil {
+		activity.Commits = commits
+	}
+
+	return SourceResult{
+		Items: activity.Items(),
+		Summary: fmt.Sprintf("%d commits, %d PRs, %d reviews, %d issues",
+			activity.TotalCommits,
+			activity.TotalPullRequests,
+			activity.TotalReviews,
+			activity.TotalIssues),
+		Data: activity,
+	}, nil
+}
+
+// Items flattens the activity into normalised items
+func (activity GitHubActivity) Items() []ActivityItem {
+	var items []ActivityItem
+
+	for _, pr := range activity.PullRequestsCreated {
+		items = append(items, pr.item(ItemKindPullRequest))
+	}
+
+	for _, issue := range activity.IssuesCreated {
+		items = append(items, ActivityItem{
+			Source:     "github",
+			Kind:       ItemKindIssue,
+			ID:         issue.URL,
+			Identifier: fmt.Sprintf("%s/%s#%d", issue.RepoOwner, issue.RepoName, issue.Number),
+			Title:      issue.Title,
+			URL:        issue.URL,
+			Repo:       fmt.Sprintf("%s/%s", issue.RepoOwner, issue.RepoName),
+			OccurredAt: issue.OccurredAt,
+		})
+	}
+
+	for _, pr := range activity.PullRequestsReviewed {
+		items = append(items, pr.item(ItemKindReview))
+	}
+
+	for _, repo := range sortedKeys(activity.CommitsByRepo) {
+		items = append(items, ActivityItem{
+			Source: "github",
+			Kind:   ItemKindCommits,
+			ID:     repo,
+			Title:  fmt.Sprintf("%s: %d commit(s)", repo, activity.CommitsByRepo[repo]),
+			Repo:   repo,
+			Count:  activity.CommitsByRepo[repo],
+		})
+	}
+
+	return items
+}
+
+// ItemAnswer is the answer given for a GitHub item
+type ItemAnswer struct {
+	Excluded bool
+	Notes    string
+}
+
+// WithAnswers applies the answers given for GitHub items, keyed by URL. Excluded items
+// are dropped from the activity and its totals, the others get the notes given.
+func (activity GitHubActivity) WithAnswers(answers map[string]ItemAnswer) GitHubActivity {
+	result := activity
+	result.Excluded = append([]string{}, activity.Excluded...)
+	excluded := make(map[string]bool)
+	for _, url := range activity.Excluded {
+		excluded[url] = true
+	}
+
+	// keep reports whether an item stays in the summary, recording it as excluded otherwise
+	keep := func(url string) (string, bool) {
+		answer := answers[url]
+		if answer.Excluded && !excluded[url] {
+			excluded[url] = true
+			result.Excluded = append(result.Excluded, url)
+		}
+		return answer.Notes, !excluded[url]
+	}
+
+	result.PullRequestsCreated = nil
+	for _, pr := range activity.PullRequestsCreated {
+		notes, ok := keep(pr.URL)
+		if !ok {
+			result.TotalPullRequests = max(0, result.TotalPullRequests-1)
+			continue
+		}
+		pr.Notes = MergeNotes(pr.Notes, notes)
+		result.PullRequestsCreated = append(result.PullRequestsCreated, pr)
+	}
+
+	result.PullRequestsReviewed = nil
+	for _, pr := range activity.PullRequestsReviewed {
+		notes, ok := keep(pr.URL)
+		if !ok {
+			result.TotalReviews = max(0, result.TotalReviews-1)
+			continue
+		}
+		pr.Notes = MergeNotes(pr.Notes, notes)
+		result.PullRequestsReviewed = append(result.PullRequestsReviewed, pr)
+	}
+
+	result.IssuesCreated = nil
+	for _, issue := range activity.IssuesCreated {
+		notes, ok := keep(issue.URL)
+		if !ok {
+			result.TotalIssues = max(0, result.TotalIssues-1)
+			continue
+		}
+		issue.Notes = MergeNotes(issue.Notes, notes)
+		result.IssuesCreated = append(result.IssuesCreated, issue)
+	}
+
+	if len(result.Excluded) == 0 {
+		result.Excluded = nil
+	}
+	return result
+}
+
+func (pr GitHubPullRequest) item(kind string) ActivityItem {
+	return ActivityItem{
+		Source:     "github",
+		Kind:       kind,
+		ID:         pr.URL,
+		Identifier: fmt.Sprintf("%s/%s#%d", pr.RepoOwner, pr.RepoName, pr.Number),
+		Title:      pr.Title,
+		URL:        pr.URL,
+		Repo:       fmt.Sprintf("%s/%s", pr.RepoOwner, pr.RepoName),
+		OccurredAt: pr.OccurredAt,
+	}
+}
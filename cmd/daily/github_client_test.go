@@ -1,6 +1,7 @@
 package daily
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -29,7 +30,7 @@ func Test_GetViewerActivity_MissingAPIToken(t *testing.T) {
 	until := time.Now()
 
 	// Act
-	_, err := GetViewerActivity(&http.Client{}, since, until, config)
+	_, err := GetViewerActivity(context.Background(), &http.Client{}, since, until, config)
 
 	// Assert
 	require.Error(t, err)
@@ -317,3 +318,34 @@ func Test_TimeRangeFormatting(t *testing.T) {
 	assert.Equal(t, "2025-10-22T08:00:00Z", sinceFormatted)
 	assert.Equal(t, "2025-10-23T08:00:00Z", untilFormatted)
 }
+
+// Test answers drop excluded items from the activity and attach notes to the others
+func Test_GitHubActivity_WithAnswers(t *testing.T) {
+	// Arrange
+	activity := GitHubActivity{
+		TotalPullRequests:    2,
+		TotalReviews:         1,
+		TotalIssues:          1,
+		PullRequestsCreated:  []GitHubPullRequest{{Title: "Add retries", URL: "https://github.com/acme/api/pull/7"}, {Title: "Bump deps", URL: "https://github.com/acme/api/pull/8"}},
+		PullRequestsReviewed: []GitHubPullRequest{{Title: "Fix typo", URL: "https://github.com/acme/web/pull/3"}},
+		IssuesCreated:        []GitHubIssue{{Title: "Flaky CI", URL: "https://github.com/acme/api/issues/9"}},
+	}
+	answers := map[string]ItemAnswer{
+		"https://github.com/acme/api/pull/7":   {Notes: "Tricky retry semantics"},
+		"https://github.com/acme/api/pull/8":   {Excluded: true},
+		"https://github.com/acme/api/issues/9": {Excluded: true},
+	}
+
+	// Act
+	result := activity.WithAnswers(answers)
+
+	// Assert
+	require.Len(t, result.PullRequestsCreated, 1)
+	assert.Equal(t, "Tricky retry semantics", result.PullRequestsCreated[0].Notes)
+	assert.Equal(t, 1, result.TotalPullRequests)
+	assert.Len(t, result.PullRequestsReviewed, 1)
+	assert.Empty(t, result.IssuesCreated)
+	assert.Equal(t, 0, result.TotalIssues)
+	assert.ElementsMatch(t, []string{"https://github.com/acme/api/pull/8", "https://github.com/acme/api/issues/9"}, result.Excluded)
+	assert.Len(t, activity.PullRequestsCreated, 2, "the original activity is left untouched")
+}
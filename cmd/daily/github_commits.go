@@ -0,0 +1,170 @@
+package daily
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// GitHubCommit is a commit the viewer pushed to the default branch of a repository
+type GitHubCommit struct {
+	Repo        string
+	Headline    string
+	URL         string
+	CommittedAt string
+}
+
+type githubViewerIDResponse struct {
+	Data struct {
+		Viewer struct {
+			ID string `json:"id"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+type githubCommitsResponse struct {
+	Data struct {
+		Viewer struct {
+			ContributionsCollection struct {
+				CommitContributionsByRepository []struct {
+					Repository struct {
+						Name  string `json:"name"`
+						Owner struct {
+							Login string `json:"login"`
+						} `json:"owner"`
+						DefaultBranchRef struct {
+							Target struct {
+								History struct {
+									Nodes []struct {
+										MessageHeadline string `json:"messageHeadline"`
+										URL             string `json:"url"`
+										CommittedDate   string `json:"committedDate"`
+									} `json:"nodes"`
+								} `json:"history"`
+							} `json:"target"`
+						} `json:"defaultBranchRef"`
+					} `json:"repository"`
+				} `json:"commitContributionsByRepository"`
+			} `json:"contributionsCollection"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+const githubViewerIDQuery = `query { viewer { id } }`
+
+const githubCommitsQuery = `
+	query($from: DateTime!, $to: DateTime!, $author: ID!) {
+		viewer {
+			contributionsCollection(from: $from, to: $to) {
+				commitContributionsByRepository(maxRepositories: 25) {
+					repository {
+						name
+						owner { login }
+						defaultBranchRef {
+							target {
+								... on Commit {
+									history(first: 100, since: $from, until: $to, author: { id: $author }) {
+										nodes { messageHeadline url committedDate }
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+`
+
+// GetViewerCommits fetches the headlines of the commits the viewer pushed to the default
+// branch of the repositories they contributed to within a time period
+func GetViewerCommits(ctx context.Context, client *http.Client, since time.Time, until time.Time, config *viper.Viper) ([]GitHubCommit, error) {
+	githubToken := config.GetString("github.apiToken")
+	if githubToken == "" {
+		return nil, fmt.Errorf("github.apiToken is not configured")
+	}
+
+	// History can only be filtered by the author's node ID, not their login
+	var viewer githubViewerIDResponse
+	if err := queryGitHub(ctx, client, githubToken, GitHubRequest{Query: githubViewerIDQuery}, &viewer); err != nil {
+		return nil, err
+	}
+	if len(viewer.Errors) > 0 {
+		return nil, fmt.Errorf("GitHub API error: %s", viewer.Errors[0].Message)
+	}
+	if viewer.Data.Viewer.ID == "" {
+		return nil, nil
+	}
+
+	var response githubCommitsResponse
+	err := queryGitHub(ctx, client, githubToken, GitHubRequest{
+		Query: githubCommitsQuery,
+		Variables: map[string]interface{}{
+			"from":   since.Format(time.RFC3339),
+			"to":     until.Format(time.RFC3339),
+			"author": viewer.Data.Viewer.ID,
+		},
+	}, &response)
+	if err != nil {
+		return nil, err
+	}
+	if len(response.Errors) > 0 {
+		return nil, fmt.Errorf("GitHub API error: %s", response.Errors[0].Message)
+	}
+
+	var commits []GitHubCommit
+	for _, contribution := range response.Data.Viewer.ContributionsCollection.CommitContributionsByRepository {
+		repo := contribution.Repository
+		for _, commit := range repo.DefaultBranchRef.Target.History.Nodes {
+			commits = append(commits, GitHubCommit{
+				Repo:        repo.Owner.Login + "/" + repo.Name,
+				Headline:    commit.MessageHeadline,
+				URL:         commit.URL,
+				CommittedAt: commit.CommittedDate,
+			})
+		}
+	}
+	return commits, nil
+}
+
+// queryGitHub posts a GraphQL request to GitHub and decodes the response into result
+func queryGitHub(ctx context.Context, client *http.Client, token string, githubRequest GitHubRequest, result interface{}) error {
+	jsonValue, err := json.Marshal(githubRequest)
+	if err != nil {
+		return fmt.Errorf("failed to create JSON payload for GraphQL request: %w", err)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, "POST", githubGraphQLURL, bytes.NewBuffer(jsonValue))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+	response, err := client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error querying GitHub's API: %w", err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response body: %w", err)
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("error unmarshalling JSON: %w", err)
+	}
+	return nil
+}
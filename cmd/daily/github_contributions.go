@@ -0,0 +1,243 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// contributionsPageSize is the most pull requests GitHub returns for one period. A period
+// that hits it is split in two and fetched again.
+const contributionsPageSize = 100
+
+// contributionsChunk is the longest period fetched at once
+const contributionsChunk = 31 * 24 * time.Hour
+
+// GitHubContributions are the viewer's GitHub contributions over a long period
+type GitHubContributions struct {
+	Username      string
+	CommitsByRepo map[string]int
+	ReviewsByRepo map[string]int
+	IssuesByRepo  map[string]int
+	// PullRequests are the pull requests created, with the size of their change
+	PullRequests []GitHubPullRequest
+	// Reviews are the pull requests reviewed, one per review, OccurredAt being when
+	Reviews []GitHubPullRequest
+	// CommitDays are the commits made each day, by repository
+	CommitDays []GitHubCommitDay
+}
+
+// GitHubCommitDay is the number of commits made to a repository on a day
+type GitHubCommitDay struct {
+	Repo string
+	// Day is the start of the day, as GitHub reports it
+	Day     string
+	Commits int
+}
+
+type githubContributionsResponse struct {
+	Data struct {
+		Viewer struct {
+			Login                   string `json:"login"`
+			ContributionsCollection struct {
+				CommitContributionsByRepository            []githubRepoContributions `json:"commitContributionsByRepository"`
+				PullRequestReviewContributionsByRepository []githubRepoContributions `json:"pullRequestReviewContributionsByRepository"`
+				IssueContributionsByRepository             []githubRepoContributions `json:"issueContributionsByRepository"`
+				PullRequestReviewContributions             struct {
+					Nodes []struct {
+						PullRequest struct {
+							URL string `json:"url"`
+						} `json:"pullRequest"`
+						OccurredAt string `json:"occurredAt"`
+					} `json:"nodes"`
+				} `json:"pullRequestReviewContributions"`
+				PullRequestContributions struct {
+					Nodes []struct {
+						PullRequest struct {
+							Title        string `json:"title"`
+							URL          string `json:"url"`
+							Number       int    `json:"number"`
+							State        string `json:"state"`
+							Additions    int    `json:"additions"`
+							Deletions    int    `json:"deletions"`
+							ChangedFiles int    `json:"changedFiles"`
+							MergedAt     string `json:"mergedAt"`
+							Repository   struct {
+								Name  string `json:"name"`
+								Owner struct {
+									Login string `json:"login"`
+								} `json:"owner"`
+							} `json:"repository"`
+						} `json:"pullRequest"`
+						OccurredAt string `json:"occurredAt"`
+					} `json:"nodes"`
+				} `json:"pullRequestContributions"`
+			} `json:"contributionsCollection"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+type githubRepoContributions struct {
+	Repository struct {
+		Name  string `json:"name"`
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+	} `json:"repository"`
+	Contributions struct {
+		TotalCount int `json:"totalCount"`
+		// Nodes are only fetched for commits, one per day
+		Nodes []struct {
+			OccurredAt  string `json:"occurredAt"`
+			CommitCount int    `json:"commitCount"`
+		} `json:"nodes"`
+	} `json:"contributions"`
+}
+
+const githubContributionsQuery = `
+	query($from: DateTime!, $to: DateTime!) {
+		viewer {
+			login
+			contributionsCollection(from: $from, to: $to) {
+				commitContributionsByRepository(maxRepositories: 100) {
+					repository { name owner { login } }
+					contributions(first: 100) { totalCount nodes { occurredAt commitCount } }
+				}
+				pullRequestReviewContributionsByRepository(maxRepositories: 100) {
+					repository { name owner { login } }
+					contributions { totalCount }
+				}
+				issueContributionsByRepository(maxRepositories: 100) {
+					repository { name owner { login } }
+					contributions { totalCount }
+				}
+				pullRequestReviewContributions(first: 100) {
+					nodes {
+						pullRequest { url }
+						occurredAt
+					}
+				}
+				pullRequestContributions(first: 100) {
+					nodes {
+						pullRequest {
+							title url number state additions deletions changedFiles mergedAt
+							repository { name owner { login } }
+						}
+						occurredAt
+					}
+				}
+			}
+		}
+	}
+`
+
+// GetViewerContributions fetches the viewer's GitHub contributions over a period of any
+// length. The period is fetched a month at a time, and busier stretches in smaller parts.
+func GetViewerContributions(ctx context.Context, client *http.Client, since time.Time, until time.Time, config *viper.Viper) (GitHubContributions, error) {
+	githubToken := config.GetString("github.apiToken")
+	if githubToken == "" {
+		return GitHubContributions{}, fmt.Errorf("github.apiToken is not configured")
+	}
+
+	contributions := GitHubContributions{
+		CommitsByRepo: make(map[string]int),
+		ReviewsByRepo: make(map[string]int),
+		IssuesByRepo:  make(map[string]int),
+	}
+	for from := since; from.Before(until); from = from.Add(contributionsChunk) {
+		to := from.Add(contributionsChunk)
+		if to.After(until) {
+			to = until
+		}
+		if err := fetchContributions(ctx, client, githubToken, from, to, &contributions); err != nil {
+			return GitHubContributions{}, err
+		}
+	}
+	return contributions, nil
+}
+
+// fetchContributions adds the contributions of one period, splitting it while GitHub
+// returns a full page of pull requests or reviews
+func fetchContributions(ctx context.Context, client *http.Client, token string, from time.Time, to time.Time, contributions *GitHubContributions) error {
+	response, err := queryContributions(ctx, client, token, from, to)
+	if err != nil {
+		return err
+	}
+
+	collection := response.Data.Viewer.ContributionsCollection
+	full := len(collection.PullRequestContributions.Nodes) >= contributionsPageSize ||
+		len(collection.PullRequestReviewContributions.Nodes) >= contributionsPageSize
+	if full && to.Sub(from) > time.Hour {
+		middle := from.Add(to.Sub(from) / 2)
+		if err := fetchContributions(ctx, client, token, from, middle, contributions); err != nil {
+			return err
+		}
+		return fetchContributions(ctx, client, token, middle, to, contributions)
+	}
+
+	contributions.Username = response.Data.Viewer.Login
+	addRepoContributions(contributions.ReviewsByRepo, collection.PullRequestReviewContributionsByRepository)
+	addRepoContributions(contributions.IssuesByRepo, collection.IssueContributionsByRepository)
+	// Commit contributions are counted in days, the commits are in each day's commitCount
+	for _, repo := range collection.CommitContributionsByRepository {
+		name := repo.Repository.Owner.Login + "/" + repo.Repository.Name
+		for _, day := range repo.Contributions.Nodes {
+			contributions.CommitsByRepo[name] += day.CommitCount
+			contributions.CommitDays = append(contributions.CommitDays, GitHubCommitDay{
+				Repo:    name,
+				Day:     day.OccurredAt,
+				Commits: day.CommitCount,
+			})
+		}
+	}
+	for _, node := range collection.PullRequestReviewContributions.Nodes {
+		contributions.Reviews = append(contributions.Reviews, GitHubPullRequest{URL: node.PullRequest.URL, OccurredAt: node.OccurredAt})
+	}
+	for _, node := range collection.PullRequestContributions.Nodes {
+		pr := node.PullRequest
+		contributions.PullRequests = append(contributions.PullRequests, GitHubPullRequest{
+			Title:        pr.Title,
+			URL:          pr.URL,
+			Number:       pr.Number,
+			State:        pr.State,
+			RepoName:     pr.Repository.Name,
+			RepoOwner:    pr.Repository.Owner.Login,
+			OccurredAt:   node.OccurredAt,
+			Additions:    pr.Additions,
+			Deletions:    pr.Deletions,
+			ChangedFiles: pr.ChangedFiles,
+			MergedAt:     pr.MergedAt,
+		})
+	}
+	return nil
+}
+
+func addRepoContributions(counts map[string]int, repos []githubRepoContributions) {
+	for _, repo := range repos {
+		counts[repo.Repository.Owner.Login+"/"+repo.Repository.Name] += repo.Contributions.TotalCount
+	}
+}
+
+func queryContributions(ctx context.Context, client *http.Client, token string, from time.Time, to time.Time) (githubContributionsResponse, error) {
+	var contributions githubContributionsResponse
+	err := queryGitHub(ctx, client, token, GitHubRequest{
+		Query: githubContributionsQuery,
+		Variables: map[string]interface{}{
+			"from": from.Format(time.RFC3339),
+			"to":   to.Format(time.RFC3339),
+		},
+	}, &contributions)
+	if err != nil {
+		return githubContributionsResponse{}, err
+	}
+	if len(contributions.Errors) > 0 {
+		return githubContributionsResponse{}, fmt.Errorf("GitHub API error: %s", contributions.Errors[0].Message)
+	}
+	return contributions, nil
+}
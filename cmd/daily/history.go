@@ -0,0 +1,213 @@
+package daily
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var historyDaysBucket = []byte("days")
+
+// History is the local database of every day's data, keyed by day
+type History struct {
+	db *bolt.DB
+}
+
+// HistoryFilter narrows down the days returned by History.Days. Zero values match everything.
+type HistoryFilter struct {
+	Since time.Time
+	Until time.Time
+	// Repo is an "owner/repo" with commits, pull requests, reviews or issues on the day
+	Repo string
+	// Identifier is a Linear issue worked on during the day
+	Identifier string
+	// Label is a label of a Linear issue worked on during the day
+	Label string
+}
+
+func historyPath(dataDir string) string {
+	return filepath.Join(dataDir, "history.db")
+}
+
+// OpenHistory opens the history database under the data directory, creating it if needed.
+// Close it once done, only one process can have it open at a time.
+func OpenHistory(dataDir string) (*History, error) {
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	db, err := bolt.Open(historyPath(dataDir), 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(historyDaysBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to prepare history: %w", err)
+	}
+
+	return &History{db: db}, nil
+}
+
+// Close releases the history database
+func (h *History) Close() error {
+	return h.db.Close()
+}
+
+// Put stores a day's data, replacing what was stored for that day
+func (h *History) Put(date time.Time, record DailyExport) error {
+	content, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode day for history: %w", err)
+	}
+
+	err = h.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(historyDaysBucket).Put([]byte(DayKey(date)), content)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save day to history: %w", err)
+	}
+	return nil
+}
+
+// Get returns the data stored for a day, or nil when there is none
+func (h *History) Get(date time.Time) (*DailyExport, error) {
+	var record *DailyExport
+	err := h.db.View(func(tx *bolt.Tx) error {
+		content := tx.Bucket(historyDaysBucket).Get([]byte(DayKey(date)))
+		if content == nil {
+			return nil
+		}
+		record = &DailyExport{}
+		return json.Unmarshal(content, record)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s from history: %w", DayKey(date), err)
+	}
+	return record, nil
+}
+
+// Days returns the stored days matching the filter, oldest first
+func (h *History) Days(filter HistoryFilter) ([]DailyExport, error) {
+	var days []DailyExport
+	err := h.db.View(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(historyDaysBucket).Cursor()
+
+		key, content := cursor.First()
+		if !filter.Since.IsZero() {
+			key, content = cursor.Seek([]byte(DayKey(filter.Since)))
+		}
+		for ; key != nil; key, content = cursor.Next() {
+			if !filter.Until.IsZero() && string(key) > DayKey(filter.Until) {
+				break
+			}
+
+			var record DailyExport
+			if err := json.Unmarshal(content, &record); err != nil {
+				return fmt.Errorf("failed to parse %s: %w", key, err)
+			}
+			if filter.Matches(record) {
+				days = append(days, record)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return days, nil
+}
+
+// Matches reports whether a day has the repository, issue and label of the filter.
+// The date range is applied by History.Days.
+func (f HistoryFilter) Matches(day DailyExport) bool {
+	if f.Repo != "" && !dayHasRepo(day, f.Repo) {
+		return false
+	}
+	if f.Identifier != "" && !dayHasIssue(day, func(issue ExportIssue) bool {
+		return strings.EqualFold(issue.Identifier, f.Identifier)
+	}) {
+		return false
+	}
+	if f.Label != "" && !dayHasIssue(day, func(issue ExportIssue) bool {
+		for _, label := range issue.Labels {
+			if strings.EqualFold(label, f.Label) {
+				return true
+			}
+		}
+		return false
+	}) {
+		return false
+	}
+	return true
+}
+
+func dayHasRepo(day DailyExport, repo string) bool {
+	var repos []string
+	for _, commits := range day.GitHub.Commits {
+		repos = append(repos, commits.Repo)
+	}
+	for _, pr := range append(append([]ExportPullRequest{}, day.GitHub.PullRequests...), day.GitHub.Reviews...) {
+		repos = append(repos, pr.Repo)
+	}
+	for _, issue := range day.GitHub.Issues {
+		repos = append(repos, issue.Repo)
+	}
+
+	for _, candidate := range repos {
+		if strings.EqualFold(candidate, repo) {
+			return true
+		}
+	}
+	return false
+}
+
+func dayHasIssue(day DailyExport, match func(ExportIssue) bool) bool {
+	for _, issue := range day.Issues {
+		if match(issue) {
+			return true
+		}
+	}
+	return false
+}
+
+// StoredDays returns the days recorded between from and to, oldest first
+func StoredDays(dataDir string, from time.Time, to time.Time) ([]DailyExport, error) {
+	history, err := OpenHistory(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	defer history.Close()
+
+	var days []DailyExport
+	for day := from; DayKey(day) <= DayKey(to); day = day.AddDate(0, 0, 1) {
+		record, err := history.Get(day)
+		if err != nil {
+			return nil, err
+		}
+		if record != nil {
+			days = append(days, *record)
+		}
+	}
+	return days, nil
+}
+
+// RecordHistory stores a day's data in the history database under the data directory
+func RecordHistory(dataDir string, date time.Time, record DailyExport) error {
+	history, err := OpenHistory(dataDir)
+	if err != nil {
+		return err
+	}
+	defer history.Close()
+
+	return history.Put(date, record)
+}
@@ -0,0 +1,91 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test days are stored, replaced and read back in order, filtered by range, repo, issue and label
+func Test_History_Days(t *testing.T) {
+	// Arrange
+	history, err := OpenHistory(t.TempDir())
+	require.NoError(t, err)
+	defer history.Close()
+
+	first := time.Date(2025, 10, 20, 18, 0, 0, 0, time.Local)
+	second := first.AddDate(0, 0, 1)
+	third := first.AddDate(0, 0, 2)
+
+	withIssue := NewDailyExport(sampleExportData())
+	require.NoError(t, history.Put(first, NewDailyExport(SummaryData{Date: first})))
+	require.NoError(t, history.Put(third, withIssue))
+	require.NoError(t, history.Put(second, withIssue))
+	require.NoError(t, history.Put(second, NewDailyExport(SummaryData{Date: second})))
+
+	// Act
+	all, err := history.Days(HistoryFilter{})
+	require.NoError(t, err)
+	ranged, err := history.Days(HistoryFilter{Since: second, Until: second})
+	require.NoError(t, err)
+	byRepo, err := history.Days(HistoryFilter{Repo: "TestOrg/Mastercrab"})
+	require.NoError(t, err)
+	byIssue, err := history.Days(HistoryFilter{Identifier: "test-123", Label: "backend"})
+	require.NoError(t, err)
+	byLabel, err := history.Days(HistoryFilter{Label: "frontend"})
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, all, 3)
+	assert.Equal(t, first.UTC(), all[0].GeneratedAt.UTC())
+	assert.Empty(t, all[1].Issues, "a later run replaces the day")
+	require.Len(t, ranged, 1)
+	assert.Equal(t, second.UTC(), ranged[0].GeneratedAt.UTC())
+	require.Len(t, byRepo, 1)
+	require.Len(t, byIssue, 1)
+	assert.Empty(t, byLabel)
+
+	day, err := history.Get(third)
+	require.NoError(t, err)
+	require.NotNil(t, day)
+	assert.Equal(t, "TEST-123", day.Issues[0].Identifier)
+	missing, err := history.Get(third.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+// Test days given on the command line
+func Test_ParseDay(t *testing.T) {
+	now := time.Date(2025, 10, 22, 18, 30, 0, 0, time.UTC)
+
+	day, err := ParseDay("yesterday", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 21, 0, 0, 0, 0, time.UTC), day)
+
+	day, err = ParseDay("2025-10-01", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC), day)
+
+	_, err = ParseDay("last week", now)
+	assert.Error(t, err)
+}
+
+// Test stored days come from the history, with day records saved before it existed imported once
+func Test_StoredDays(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	monday := time.Date(2025, 10, 20, 18, 0, 0, 0, time.Local)
+	require.NoError(t, RecordHistory(dataDir, monday, NewDailyExport(SummaryData{Date: monday})))
+	require.NoError(t, RecordHistory(dataDir, monday.AddDate(0, 0, 2), NewDailyExport(SummaryData{Date: monday.AddDate(0, 0, 2)})))
+
+	// Act
+	days, err := StoredDays(dataDir, monday, monday.AddDate(0, 0, 4))
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, days, 2)
+	assert.Equal(t, DayKey(monday), DayKey(days[0].GeneratedAt))
+	assert.Equal(t, DayKey(monday.AddDate(0, 0, 2)), DayKey(days[1].GeneratedAt))
+}
@@ -0,0 +1,102 @@
+package daily
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// stdin is the only reader of os.Stdin for the prompts. They read it through
+// stdin.WithContext(ctx), so a prompt given up on Ctrl-C leaves nothing behind that
+// would take the answer to the next one.
+var stdin = newLineInput(os.Stdin)
+
+// lineInput reads its source a line at a time from a single goroutine, and only when a
+// prompt asks for a line, so the terminal is left alone for the editor and the TUI
+type lineInput struct {
+	source   io.Reader
+	start    sync.Once
+	requests chan struct{}
+	lines    chan inputLine
+
+	mu sync.Mutex
+	// waiting is set while a line was asked for and not received yet
+	waiting bool
+	// pending is the part of the last line not read yet
+	pending []byte
+	// err ended the input, it is returned once pending is read
+	err error
+}
+
+type inputLine struct {
+	text []byte
+	err  error
+}
+
+func newLineInput(source io.Reader) *lineInput {
+	return &lineInput{
+		source:   source,
+		requests: make(chan struct{}, 1),
+		lines:    make(chan inputLine, 1),
+	}
+}
+
+func (in *lineInput) run() {
+	reader := bufio.NewReader(in.source)
+	for range in.requests {
+		text, err := reader.ReadBytes('\n')
+		in.lines <- inputLine{text: text, err: err}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// WithContext returns a reader of the input that gives up with ctx's error once ctx is
+// done. A line that arrives after that goes to the next read. Each read returns at most
+// one line, so a bufio.Reader on top of it never reads ahead of its prompt.
+func (in *lineInput) WithContext(ctx context.Context) io.Reader {
+	return contextReader{input: in, ctx: ctx}
+}
+
+type contextReader struct {
+	input *lineInput
+	ctx   context.Context
+}
+
+func (r contextReader) Read(p []byte) (int, error) {
+	in := r.input
+	in.start.Do(func() { go in.run() })
+
+	in.mu.Lock()
+	defer in.mu.Unlock()
+
+	if len(in.pending) == 0 {
+		if in.err != nil {
+			return 0, in.err
+		}
+		if err := r.ctx.Err(); err != nil {
+			return 0, err
+		}
+		if !in.waiting {
+			in.waiting = true
+			in.requests <- struct{}{}
+		}
+		select {
+		case line := <-in.lines:
+			in.waiting = false
+			in.pending, in.err = line.text, line.err
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+		if len(in.pending) == 0 {
+			return 0, in.err
+		}
+	}
+
+	n := copy(p, in.pending)
+	in.pending = in.pending[n:]
+	return n, nil
+}
@@ -0,0 +1,55 @@
+package daily
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test prompts one after the other each get their own line, none being read ahead
+func Test_LineInput_Prompts(t *testing.T) {
+	// Arrange
+	input := newLineInput(strings.NewReader("y\nFixed the build\n\n2h\n"))
+	ctx := context.Background()
+
+	// Act
+	notes, worked, err := promptForAnswer(input.WithContext(ctx), "Did you work on this issue today?", "Please describe what you did on this issue:", "", nil)
+	spent, spentErr := PromptForTimeSpent(input.WithContext(ctx), 0)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, worked)
+	assert.Equal(t, "Fixed the build", notes)
+	require.NoError(t, spentErr)
+	assert.Equal(t, 2*time.Hour, spent)
+}
+
+// Test a prompt given up on cancellation leaves the next line to the next prompt
+func Test_LineInput_Cancelled(t *testing.T) {
+	// Arrange
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	input := newLineInput(reader)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, _, err := promptForAnswer(input.WithContext(ctx), "Did you work on this issue today?", "Please describe what you did on this issue:", "", nil)
+		cancelled <- err
+	}()
+
+	// Act
+	cancel()
+	err := <-cancelled
+	go writer.Write([]byte("n\n"))
+	_, worked, nextErr := promptForAnswer(input.WithContext(context.Background()), "Include this in the summary?", "Add notes about it, e.g. why it mattered:", "", nil)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, nextErr)
+	assert.False(t, worked)
+}
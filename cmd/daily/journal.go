@@ -0,0 +1,167 @@
+package daily
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// JournalEntry is a note captured during the day with "crab note"
+type JournalEntry struct {
+	At time.Time `json:"at"`
+	// Identifier is the Linear issue the note is about, empty for unlinked notes
+	Identifier string `json:"identifier,omitempty"`
+	Text       string `json:"text"`
+}
+
+// Journal is the notes captured within a window, oldest first
+type Journal []JournalEntry
+
+var identifierPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*-[0-9]+$`)
+
+// ParseNoteArgs splits "crab note" arguments into an optional Linear identifier and the note text
+func ParseNoteArgs(args []string) (identifier string, text string, err error) {
+	if len(args) > 1 && identifierPattern.MatchString(args[0]) {
+		identifier = strings.ToUpper(args[0])
+		args = args[1:]
+	}
+
+	text = strings.TrimSpace(strings.Join(args, " "))
+	if text == "" || (identifier == "" && identifierPattern.MatchString(text)) {
+		return "", "", fmt.Errorf("nothing to note, add some text after the identifier")
+	}
+	return identifier, text, nil
+}
+
+func journalPath(dataDir string, date time.Time) string {
+	return filepath.Join(dataDir, "journal", DayKey(date)+".jsonl")
+}
+
+// AppendJournalEntry adds an entry to the journal of the day it was captured on
+func AppendJournalEntry(dataDir string, entry JournalEntry) error {
+	path := journalPath(dataDir, entry.At)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create journal directory: %w", err)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode note: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open journal: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+	return nil
+}
+
+// LoadJournal reads the notes captured within the window
+func LoadJournal(dataDir string, window Window) (Journal, error) {
+	var journal Journal
+
+	day := time.Date(window.Since.Year(), window.Since.Month(), window.Since.Day(), 0, 0, 0, 0, window.Since.Location())
+	for !day.After(window.Until) {
+		entries, err := readJournalFile(journalPath(dataDir, day))
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.At.Before(window.Since) && !entry.At.After(window.Until) {
+				journal = append(journal, entry)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return journal, nil
+}
+
+func readJournalFile(path string) ([]JournalEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	defer file.Close()
+
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse %s line %d: %w", path, line, err)
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read journal: %w", err)
+	}
+	return entries, nil
+}
+
+// NotesFor joins the notes captured for a Linear identifier, one per line
+func (j Journal) NotesFor(identifier string) string {
+	var notes []string
+	for _, entry := range j {
+		if identifier != "" && strings.EqualFold(entry.Identifier, identifier) {
+			notes = append(notes, entry.Text)
+		}
+	}
+	return strings.Join(notes, "\n")
+}
+
+// ApplyJournal adds the journal to summary data once the run's answers are in.
+// reviewed holds the (upper-case) identifiers of the issues reviewed in this run, whose
+// notes already include the journal. Notes for other issues of the day are merged into
+// them, and notes about no known issue, or about no issue at all, become free-form entries.
+func ApplyJournal(data *SummaryData, journal Journal, reviewed map[string]bool) {
+	known := make(map[string]bool)
+	for identifier := range reviewed {
+		known[identifier] = true
+	}
+	for i, issue := range data.Issues {
+		identifier := strings.ToUpper(issue.Details.Identifier)
+		if !reviewed[identifier] {
+			data.Issues[i].UserNotes = MergeNotes(issue.UserNotes, journal.NotesFor(identifier))
+		}
+		known[identifier] = true
+	}
+	for _, issue := range data.NotWorked {
+		known[strings.ToUpper(issue.Identifier)] = true
+	}
+
+	existing := make(map[string]bool)
+	for _, entry := range data.Entries {
+		existing[entry.key()] = true
+	}
+	for _, note := range journal {
+		if note.Identifier != "" && known[strings.ToUpper(note.Identifier)] {
+			continue
+		}
+
+		entry := FreeFormEntry{Text: note.Text, At: note.At}
+		if note.Identifier != "" {
+			entry.Text = note.Identifier + ": " + note.Text
+		}
+		if !existing[entry.key()] {
+			existing[entry.key()] = true
+			data.Entries = append(data.Entries, entry)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package daily
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test a leading Linear identifier links the note to that issue
+func Test_ParseNoteArgs(t *testing.T) {
+	identifier, text, err := ParseNoteArgs([]string{"eng-123", "fixed", "flaky test"})
+	require.NoError(t, err)
+	assert.Equal(t, "ENG-123", identifier)
+	assert.Equal(t, "fixed flaky test", text)
+
+	identifier, text, err = ParseNoteArgs([]string{"pairing with Sam"})
+	require.NoError(t, err)
+	assert.Empty(t, identifier)
+	assert.Equal(t, "pairing with Sam", text)
+
+	_, _, err = ParseNoteArgs([]string{"ENG-123"})
+	assert.Error(t, err, "an identifier alone is not a note")
+	_, _, err = ParseNoteArgs(nil)
+	assert.Error(t, err)
+}
+
+// Test notes are read back across day files, limited to the window
+func Test_Journal_AppendAndLoad(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	until := time.Date(2025, 10, 22, 18, 0, 0, 0, time.UTC)
+	window := Window{Since: until.Add(-24 * time.Hour), Until: until}
+	for _, entry := range []JournalEntry{
+		{At: until.Add(-30 * time.Hour), Text: "too old"},
+		{At: until.Add(-20 * time.Hour), Identifier: "ENG-1", Text: "started the migration"},
+		{At: until.Add(-2 * time.Hour), Identifier: "ENG-1", Text: "finished the migration"},
+		{At: until.Add(-1 * time.Hour), Text: "pairing with Sam"},
+	} {
+		require.NoError(t, AppendJournalEntry(dataDir, entry))
+	}
+
+	// Act
+	journal, err := LoadJournal(dataDir, window)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, journal, 3)
+	assert.Equal(t, "started the migration\nfinished the migration", journal.NotesFor("eng-1"))
+	assert.Empty(t, journal.NotesFor("ENG-2"))
+}
+
+// Test journal notes reach issues that were not reviewed and the other work section
+func Test_ApplyJournal(t *testing.T) {
+	// Arrange
+	at := time.Date(2025, 10, 22, 10, 0, 0, 0, time.UTC)
+	data := sampleSummaryData()
+	data.Issues = append(data.Issues, IssueWithNotes{Details: LinearIssueDetails{Identifier: "TEST-7", Title: "Earlier run"}, UserNotes: "Planned it"})
+	journal := Journal{
+		{At: at, Identifier: "TEST-123", Text: "Added token refresh"},
+		{At: at, Identifier: "TEST-7", Text: "Shipped it"},
+		{At: at, Identifier: "OPS-9", Text: "Rotated the keys"},
+		{At: at, Text: "Interviewed a candidate"},
+	}
+
+	// Act - applying twice must not duplicate anything
+	ApplyJournal(&data, journal, map[string]bool{"TEST-123": true})
+	ApplyJournal(&data, journal, map[string]bool{"TEST-123": true})
+
+	// Assert
+	assert.Equal(t, "Added token refresh\n\n  Wrote tests  ", data.Issues[0].UserNotes, "reviewed issues already include the journal")
+	assert.Equal(t, "Planned it\nShipped it", data.Issues[1].UserNotes)
+	require.Len(t, data.Entries, 2)
+	assert.Equal(t, "OPS-9: Rotated the keys", data.Entries[0].Text)
+	assert.Equal(t, "Interviewed a candidate", data.Entries[1].Text)
+
+	var out bytes.Buffer
+	require.NoError(t, RenderSummary(&out, data, ""))
+	assert.Contains(t, out.String(), "## Other Work\n\n- OPS-9: Rotated the keys\n- Interviewed a candidate\n")
+}
@@ -2,10 +2,12 @@ package daily
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -19,6 +21,9 @@ type LinearViewerResponse struct {
 	Data struct {
 		LinearViewer
 	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
 }
 
 type LinearViewer struct {
@@ -78,9 +83,43 @@ type LinearIssueDetails struct {
 	} `json:"assignee"`
 	CreatedAt string `json:"createdAt"`
 	UpdatedAt string `json:"updatedAt"`
+	Project   struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	History struct {
+		Nodes []LinearIssueHistory `json:"nodes"`
+	} `json:"history"`
+}
+
+// LinearIssueHistory is a change made to an issue. Only state changes have FromState and ToState.
+type LinearIssueHistory struct {
+	CreatedAt string `json:"createdAt"`
+	FromState *struct {
+		Name string `json:"name"`
+	} `json:"fromState"`
+	ToState *struct {
+		Name string `json:"name"`
+	} `json:"toState"`
 }
 
-func GetViewerAssignedIssues(client *http.Client, date_filter string, config *viper.Viper) (LinearViewer, error) {
+func GetViewerAssignedIssues(ctx context.Context, client *http.Client, date_filter string, config *viper.Viper) (LinearViewer, error) {
+	// If date_filter is empty, default to the last 24 hours
+	if date_filter == "" {
+		date_filter = "-P1D"
+	}
+
+	return getViewerAssignedIssues(ctx, client, fmt.Sprintf(`gte: "%s"`, date_filter), config)
+}
+
+// GetViewerIssuesUpdatedWithin fetches the issues assigned to the viewer that were updated
+// between the start and the end of the window
+func GetViewerIssuesUpdatedWithin(ctx context.Context, client *http.Client, window Window, config *viper.Viper) (LinearViewer, error) {
+	return getViewerAssignedIssues(ctx, client, LinearDateFilter(window), config)
+}
+
+// getViewerAssignedIssues fetches the viewer's assigned issues whose updatedAt matches the
+// comparator, such as `gte: "-P1D"`
+func getViewerAssignedIssues(ctx context.Context, client *http.Client, updatedAt string, config *viper.Viper) (LinearViewer, error) {
 	// Get required config values
 	linearAuth := config.GetString("linear.apiToken")
 	baseURL := config.GetString("linear.baseURL")
@@ -93,18 +132,13 @@ func GetViewerAssignedIssues(client *http.Client, date_filter string, config *vi
 		return LinearViewer{}, fmt.Errorf("linear.apiToken is not configured")
 	}
 
-	// If date_filter is empty, default to the last 24 hours
-	if date_filter == "" {
-		date_filter = "-P1D"
-	}
-
 	// Build GraphQL request
 	var operationName = "MyAssignedIssues"
 	linearRequest := LinearViewerRequest{
 		Query: fmt.Sprintf(`
 			query %s {
 				viewer {
-					assignedIssues(filter: { updatedAt: { gte: "%s" }}) {
+					assignedIssues(filter: { updatedAt: { %s }}) {
 						edges {
 							node {
 								id title url
@@ -113,7 +147,7 @@ func GetViewerAssignedIssues(client *http.Client, date_filter string, config *vi
 					}
 				}
 			}
-		`, operationName, date_filter),
+		`, operationName, updatedAt),
 		OperationName: operationName,
 	}
 
@@ -123,7 +157,7 @@ func GetViewerAssignedIssues(client *http.Client, date_filter string, config *vi
 	}
 
 	// Create HTTP request
-	request, err := http.NewRequest("POST", baseURL, bytes.NewBuffer(jsonValue))
+	request, err := http.NewRequestWithContext(ctx, "POST", baseURL, bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return LinearViewer{}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -134,25 +168,35 @@ func GetViewerAssignedIssues(client *http.Client, date_filter string, config *vi
 	// Execute request
 	response, err := client.Do(request)
 	if err != nil {
-		fmt.Printf("Error querying Linear's API %s\n", err)
-		return LinearViewer{}, err
+		return LinearViewer{}, fmt.Errorf("error querying Linear's API: %w", err)
 	}
 
 	defer response.Body.Close()
-	data, _ := io.ReadAll(response.Body)
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return LinearViewer{}, fmt.Errorf("error reading response body: %w", err)
+	}
 
 	var viewer LinearViewerResponse
-	err = json.Unmarshal(data, &viewer)
-	if err != nil {
-		fmt.Printf("Error unmarshalling JSON %s\n", err)
-		return LinearViewer{}, err
+	unmarshalErr := json.Unmarshal(data, &viewer)
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		if unmarshalErr == nil && len(viewer.Errors) > 0 {
+			return LinearViewer{}, fmt.Errorf("Linear API error (%s): %s", response.Status, viewer.Errors[0].Message)
+		}
+		return LinearViewer{}, fmt.Errorf("Linear API error: %s", response.Status)
+	}
+	if unmarshalErr != nil {
+		return LinearViewer{}, fmt.Errorf("error unmarshalling JSON: %w", unmarshalErr)
+	}
+	if len(viewer.Errors) > 0 {
+		return LinearViewer{}, fmt.Errorf("Linear API error: %s", viewer.Errors[0].Message)
 	}
 
 	return viewer.Data.LinearViewer, nil
 }
 
 // GetIssueDetails fetches detailed information for a single issue by ID
-func GetIssueDetails(client *http.Client, issueID string, config *viper.Viper) (LinearIssueDetails, error) {
+func GetIssueDetails(ctx context.Context, client *http.Client, issueID string, config *viper.Viper) (LinearIssueDetails, error) {
 	// Get required config values
 	linearAuth := config.GetString("linear.apiToken")
 	baseURL := config.GetString("linear.baseURL")
@@ -185,6 +229,20 @@ func GetIssueDetails(client *http.Client, issueID string, config *viper.Viper) (
 					}
 					priority
 					priorityLabel
+					project {
+						name
+					}
+					history(first: 50) {
+						nodes {
+							createdAt
+							fromState {
+								name
+							}
+							toState {
+								name
+							}
+						}
+					}
 					labels {
 						nodes {
 							name
@@ -220,7 +278,7 @@ func GetIssueDetails(client *http.Client, issueID string, config *viper.Viper) (
 	}
 
 	// Create HTTP request
-	request, err := http.NewRequest("POST", baseURL, bytes.NewBuffer(jsonValue))
+	request, err := http.NewRequestWithContext(ctx, "POST", baseURL, bytes.NewBuffer(jsonValue))
 	if err != nil {
 		return LinearIssueDetails{}, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
@@ -245,3 +303,166 @@ func GetIssueDetails(client *http.Client, issueID string, config *viper.Viper) (
 
 	return issueResponse.Data.Issue, nil
 }
+
+// LinearCompletedIssue is an issue assigned to the viewer that was completed
+type LinearCompletedIssue struct {
+	ID          string `json:"id"`
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	CompletedAt string `json:"completedAt"`
+	Project     struct {
+		Name string `json:"name"`
+	} `json:"project"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+}
+
+type linearCompletedIssuesResponse struct {
+	Data struct {
+		Viewer struct {
+			AssignedIssues struct {
+				Nodes    []LinearCompletedIssue `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"assignedIssues"`
+		} `json:"viewer"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors,omitempty"`
+}
+
+// GetCompletedIssues fetches every issue assigned to the viewer that was completed within
+// the period, a page at a time
+func GetCompletedIssues(ctx context.Context, client *http.Client, since time.Time, until time.Time, config *viper.Viper) ([]LinearCompletedIssue, error) {
+	// Get required config values
+	linearAuth := config.GetString("linear.apiToken")
+	baseURL := config.GetString("linear.baseURL")
+
+	// Validate required config
+	if baseURL == "" {
+		return nil, fmt.Errorf("linear.baseURL is not configured")
+	}
+	if linearAuth == "" {
+		return nil, fmt.Errorf("linear.apiToken is not configured")
+	}
+
+	var issues []LinearCompletedIssue
+	cursor := ""
+	for {
+		after := ""
+		if cursor != "" {
+			after = fmt.Sprintf(`, after: "%s"`, cursor)
+		}
+
+		var operationName = "MyCompletedIssues"
+		linearRequest := LinearViewerRequest{
+			Query: fmt.Sprintf(`
+				query %s {
+					viewer {
+						assignedIssues(first: 100%s, filter: { completedAt: { gte: "%s", lte: "%s" }}) {
+							nodes {
+								id identifier title url completedAt
+								project { name }
+								labels { nodes { name } }
+							}
+							pageInfo { hasNextPage endCursor }
+						}
+					}
+				}
+			`, operationName, after, since.UTC().Format(time.RFC3339), until.UTC().Format(time.RFC3339)),
+			OperationName: operationName,
+		}
+
+		jsonValue, err := json.Marshal(linearRequest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create JSON payload for GraphQL request: %w", err)
+		}
+
+		request, err := http.NewRequestWithContext(ctx, "POST", baseURL, bytes.NewBuffer(jsonValue))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+		}
+		request.Header.Set("Content-Type", "application/json")
+		request.Header.Set("Authorization", linearAuth)
+
+		response, err := client.Do(request)
+		if err != nil {
+			return nil, fmt.Errorf("error querying Linear's API: %w", err)
+		}
+		data, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("error reading response body: %w", err)
+		}
+
+		var page linearCompletedIssuesResponse
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("error unmarshalling JSON: %w", err)
+		}
+		if len(page.Errors) > 0 {
+			return nil, fmt.Errorf("Linear API error: %s", page.Errors[0].Message)
+		}
+
+		connection := page.Data.Viewer.AssignedIssues
+		issues = append(issues, connection.Nodes...)
+		if !connection.PageInfo.HasNextPage || connection.PageInfo.EndCursor == "" {
+			return issues, nil
+		}
+		cursor = connection.PageInfo.EndCursor
+	}
+}
+
+// LinearSource exposes the viewer's assigned Linear issues as an ActivitySource
+type LinearSource struct{}
+
+func init() {
+	RegisterSource(LinearSource{})
+}
+
+// Name returns the config key of the source
+func (LinearSource) Name() string {
+	return "linear"
+}
+
+// Configured reports whether a Linear token and endpoint are available
+func (LinearSource) Configured(config *viper.Viper) bool {
+	return config.GetString("linear.apiToken") != "" && config.GetString("linear.baseURL") != ""
+}
+
+// Fetch retrieves the issues assigned to the viewer that were updated within the window
+func (LinearSource) Fetch(ctx context.Context, client *http.Client, window Window, config *viper.Viper) (SourceResult, error) {
+	viewer, err := GetViewerIssuesUpdatedWithin(ctx, client, window, config)
+	if err != nil {
+		return SourceResult{}, err
+	}
+
+	var items []ActivityItem
+	for _, edge := range viewer.Viewer.AssignedIssues.Edges {
+		items = append(items, ActivityItem{
+			Source: "linear",
+			Kind:   ItemKindLinearIssue,
+			ID:     edge.Node.ID,
+			Title:  edge.Node.Title,
+			URL:    edge.Node.URL,
+		})
+	}
+
+	return SourceResult{
+		Items:   items,
+		Summary: fmt.Sprintf("%d issue(s) updated", len(items)),
+		Data:    viewer,
+	}, nil
+}
+
+// LinearDateFilter converts a window into a Linear date comparator bounded by both ends of
+// the window, so past windows are not counted back from now
+func LinearDateFilter(window Window) string {
+	return fmt.Sprintf(`gte: "%s", lte: "%s"`, window.Since.UTC().Format(time.RFC3339), window.Until.UTC().Format(time.RFC3339))
+}
@@ -1,11 +1,13 @@
 package daily
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -28,7 +30,7 @@ func Test_GetViewerAssignedIssues_MissingBaseURL(t *testing.T) {
 	// baseURL is intentionally not set
 
 	// Act
-	_, err := GetViewerAssignedIssues(&http.Client{}, "-P1D", config)
+	_, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
 
 	// Assert
 	require.Error(t, err)
@@ -43,7 +45,7 @@ func Test_GetViewerAssignedIssues_MissingAPIToken(t *testing.T) {
 	// apiToken is intentionally not set
 
 	// Act
-	_, err := GetViewerAssignedIssues(&http.Client{}, "-P1D", config)
+	_, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
 
 	// Assert
 	require.Error(t, err)
@@ -82,7 +84,7 @@ func Test_GetViewerAssignedIssues_SuccessWithMockFile(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "test-api-token")
 
 	// Act
-	result, err := GetViewerAssignedIssues(&http.Client{}, "-P1D", config)
+	result, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
 
 	// Assert
 	require.NoError(t, err)
@@ -145,7 +147,7 @@ func Test_GetViewerAssignedIssues_EmptyResponse(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "test-token")
 
 	// Act
-	result, err := GetViewerAssignedIssues(&http.Client{}, "-P1D", config)
+	result, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
 
 	// Assert
 	require.NoError(t, err)
@@ -177,14 +179,34 @@ func Test_GetViewerAssignedIssues_APIError(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "invalid-token")
 
 	// Act
-	result, err := GetViewerAssignedIssues(&http.Client{}, "-P1D", config)
+	result, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
 
 	// Assert
-	require.NoError(t, err) // API returns 401 but HTTP request succeeds
-	// The result will have nil edges because the error response doesn't match our struct
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+	assert.Contains(t, err.Error(), "Authentication required")
 	assert.Nil(t, result.Viewer.AssignedIssues.Edges)
 }
 
+// Test GetViewerAssignedIssues fails on GraphQL errors returned with a 200
+func Test_GetViewerAssignedIssues_GraphQLError(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":null,"errors":[{"message":"Field 'assignedIssues' is invalid"}]}`))
+	}))
+	defer mockServer.Close()
+
+	config := createTestConfig(mockServer.URL, "test-api-token")
+
+	// Act
+	_, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P1D", config)
+
+	// Assert
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Field 'assignedIssues' is invalid")
+}
+
 // Test GetViewerAssignedIssues with custom date filter
 func Test_GetViewerAssignedIssues_CustomDateFilter(t *testing.T) {
 	// Arrange
@@ -204,7 +226,7 @@ func Test_GetViewerAssignedIssues_CustomDateFilter(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "test-token")
 
 	// Act
-	_, err := GetViewerAssignedIssues(&http.Client{}, "-P7D", config)
+	_, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "-P7D", config)
 
 	// Assert
 	require.NoError(t, err)
@@ -230,13 +252,43 @@ func Test_GetViewerAssignedIssues_DefaultDateFilter(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "test-token")
 
 	// Act - pass empty string for date filter
-	_, err := GetViewerAssignedIssues(&http.Client{}, "", config)
+	_, err := GetViewerAssignedIssues(context.Background(), &http.Client{}, "", config)
 
 	// Assert
 	require.NoError(t, err)
 	assert.Contains(t, capturedQuery, "-P1D", "Query should contain default date filter -P1D")
 }
 
+// Test the Linear source bounds a past window on both ends instead of counting back from now
+func Test_LinearSource_Fetch_PastWindow(t *testing.T) {
+	// Arrange
+	var capturedQuery string
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var requestBody LinearViewerRequest
+		json.NewDecoder(r.Body).Decode(&requestBody)
+		capturedQuery = requestBody.Query
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(LinearViewerResponse{})
+	}))
+	defer mockServer.Close()
+
+	config := createTestConfig(mockServer.URL, "test-token")
+	window := Window{
+		Since: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2025, 3, 16, 23, 59, 59, 0, time.UTC),
+	}
+
+	// Act
+	_, err := LinearSource{}.Fetch(context.Background(), &http.Client{}, window, config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, capturedQuery, `updatedAt: { gte: "2025-03-10T00:00:00Z", lte: "2025-03-16T23:59:59Z" }`)
+	assert.NotContains(t, capturedQuery, "-P")
+}
+
 // Test JSON unmarshalling with actual mock response file
 func Test_UnmarshalMockResponse(t *testing.T) {
 	// Arrange
@@ -265,7 +317,7 @@ func Test_GetIssueDetails_MissingIssueID(t *testing.T) {
 	config := createTestConfig("https://api.linear.app/graphql", "test-token")
 
 	// Act
-	_, err := GetIssueDetails(&http.Client{}, "", config)
+	_, err := GetIssueDetails(context.Background(), &http.Client{}, "", config)
 
 	// Assert
 	require.Error(t, err)
@@ -307,7 +359,7 @@ func Test_GetIssueDetails_Success(t *testing.T) {
 
 	// Act
 	issueID := "test-issue-id-001"
-	result, err := GetIssueDetails(&http.Client{}, issueID, config)
+	result, err := GetIssueDetails(context.Background(), &http.Client{}, issueID, config)
 
 	// Assert
 	require.NoError(t, err)
@@ -358,7 +410,7 @@ func Test_GetIssueDetails_APIError(t *testing.T) {
 	config := createTestConfig(mockServer.URL, "test-token")
 
 	// Act
-	result, err := GetIssueDetails(&http.Client{}, "invalid-id", config)
+	result, err := GetIssueDetails(context.Background(), &http.Client{}, "invalid-id", config)
 
 	// Assert
 	require.NoError(t, err) // HTTP request succeeds even if API returns error
@@ -0,0 +1,839 @@
+package daily
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StdoutPath is the --output value that writes the summary to stdout
+const StdoutPath = "-"
+
+// What to do when the summary file already exists
+const (
+	ExistingAsk       = "ask"
+	ExistingOverwrite = "overwrite"
+	ExistingAppend    = "append"
+	ExistingMerge     = "merge"
+	ExistingCancel    = "cancel"
+)
+
+// ParseExistingAction validates an --on-exists value
+func ParseExistingAction(action string) (string, error) {
+	switch strings.ToLower(action) {
+	case "", ExistingAsk:
+		return ExistingAsk, nil
+	case ExistingOverwrite, ExistingAppend, ExistingMerge, ExistingCancel:
+		return strings.ToLower(action), nil
+	}
+	return "", fmt.Errorf("unknown --on-exists value %q (expected ask, overwrite, append, merge or cancel)", action)
+}
+
+// SummaryFilename is the default file name of a day's summary
+func SummaryFilename(date time.Time, format string) string {
+	if format == FormatStandup {
+		return fmt.Sprintf("daily-standup-%s.%s", date.Format("2006-01-02"), FormatExtension(format))
+	}
+	return fmt.Sprintf("daily-summary-%s.%s", date.Format("2006-01-02"), FormatExtension(format))
+}
+
+// ReportFilename is the default file name of a report covering from to to
+func ReportFilename(from time.Time, to time.Time, format string) string {
+	return fmt.Sprintf("work-report-%s-to-%s.%s", DayKey(from), DayKey(to), FormatExtension(format))
+}
+
+// ResolveOutputPath decides where the summary goes. An explicit output wins; "-" means stdout
+// and a directory receives the default file name. Otherwise the file is placed in dir
+// (the "output.dir" config key), defaulting to the current directory.
+func ResolveOutputPath(output string, dir string, date time.Time, format string) (string, error) {
+	return resolveOutputPath(output, dir, SummaryFilename(date, format))
+}
+
+// ResolveReportPath decides where a report goes, the same way as ResolveOutputPath
+func ResolveReportPath(output string, dir string, from time.Time, to time.Time, format string) (string, error) {
+	return resolveOutputPath(output, dir, ReportFilename(from, to, format))
+}
+
+func resolveOutputPath(output string, dir string, filename string) (string, error) {
+	if output == StdoutPath {
+		return StdoutPath, nil
+	}
+
+	if output != "" {
+		info, err := os.Stat(output)
+		if err == nil && info.IsDir() {
+			return filepath.Join(output, filename), nil
+		}
+		return output, nil
+	}
+
+	if dir == "" {
+		dir = "."
+	}
+	dir, err := expandHome(dir)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+	return filepath.Join(dir, filename), nil
+}
+
+// PromptExistingAction asks what to do with a summary file that already exists
+func PromptExistingAction(input io.Reader, path string) (string, error) {
+	reader := bufio.NewReader(input)
+
+	fmt.Fprintf(console, "\n⚠️  %s already exists.\n", path)
+	fmt.Fprintln(console, "❓ (o)verwrite, (a)ppend, (m)erge or (c)ancel?")
+	fmt.Fprint(console, "▶ ")
+
+	response, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.TrimSpace(strings.ToLower(response)) {
+	case "o", ExistingOverwrite:
+		return ExistingOverwrite, nil
+	case "a", ExistingAppend:
+		return ExistingAppend, nil
+	case "m", ExistingMerge:
+		return ExistingMerge, nil
+	}
+	return ExistingCancel, nil
+}
+
+// WriteSummaryOutput renders the data and writes it to path according to action.
+// action must already be resolved, i.e. not ExistingAsk, when path exists.
+func WriteSummaryOutput(data SummaryData, format string, templatePath string, path string, action string) error {
+	var rendered bytes.Buffer
+	if err := WriteFormatted(&rendered, data, format, templatePath); err != nil {
+		return err
+	}
+
+	return WriteRenderedOutput(rendered.Bytes(), format, path, action)
+}
+
+// WriteRenderedOutput writes an already rendered document to path according to action, the
+// way WriteSummaryOutput does. The file is replaced in one go, so a failed write leaves the
+// existing one as it was.
+func WriteRenderedOutput(rendered []byte, format string, path string, action string) error {
+	if path == StdoutPath {
+		_, err := os.Stdout.Write(rendered)
+		return err
+	}
+
+	existing, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		action, err = ExistingOverwrite, nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read existing summary: %w", err)
+	}
+
+	var content []byte
+	switch action {
+	case ExistingOverwrite:
+		content = rendered
+	case ExistingAppend:
+		content, err = appendSummary(existing, rendered, format)
+	case ExistingMerge:
+		content, err = mergeSummary(existing, rendered, format)
+	case ExistingCancel:
+		return fmt.Errorf("%s already exists, not overwriting", path)
+	default:
+		return fmt.Errorf("%s already exists, use --on-exists to overwrite, append or merge", path)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(path, content); err != nil {
+		return fmt.Errorf("failed to write summary file: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes content next to path and renames it over path once complete
+func writeFileAtomic(path string, content []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Chmod(0o644); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
+
+func renderedPath(dataDir string) string {
+	return filepath.Join(dataDir, "rendered.json")
+}
+
+// loadRendered reads the checksums of the summary files written by earlier runs, by path
+func loadRendered(dataDir string) (map[string]string, error) {
+	rendered := make(map[string]string)
+	content, err := os.ReadFile(renderedPath(dataDir))
+	if os.IsNotExist(err) {
+		return rendered, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read written summaries: %w", err)
+	}
+	if err := json.Unmarshal(content, &rendered); err != nil {
+		return nil, fmt.Errorf("failed to parse written summaries: %w", err)
+	}
+	return rendered, nil
+}
+
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// RecordRender remembers the content of the summary file at path as last written by
+// mastercrab, so a later run can tell whether it was edited since
+func RecordRender(dataDir string, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read written summary: %w", err)
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+
+	rendered, err := loadRendered(dataDir)
+	if err != nil {
+		return err
+	}
+	rendered[absolute] = checksum(content)
+
+	encoded, err := json.MarshalIndent(rendered, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode written summaries: %w", err)
+	}
+	if err := os.MkdirAll(dataDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+	if err := os.WriteFile(renderedPath(dataDir), encoded, 0o644); err != nil {
+		return fmt.Errorf("failed to save written summaries: %w", err)
+	}
+	return nil
+}
+
+// UnchangedSinceRender reports whether the file at path is byte for byte what mastercrab
+// last wrote there. Files it never wrote, and files edited since, are not.
+func UnchangedSinceRender(dataDir string, path string) bool {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	absolute, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	rendered, err := loadRendered(dataDir)
+	if err != nil {
+		return false
+	}
+	return rendered[absolute] == checksum(content)
+}
+
+func appendSummary(existing []byte, rendered []byte, format string) ([]byte, error) {
+	switch format {
+	case FormatJSON:
+		return nil, fmt.Errorf("append is not supported for JSON, use merge or overwrite")
+	case FormatYAML:
+		return joinDocuments(existing, []byte("---\n"), rendered), nil
+	}
+	return joinDocuments(existing, []byte("\n---\n\n"), rendered), nil
+}
+
+func joinDocuments(existing []byte, separator []byte, rendered []byte) []byte {
+	var content bytes.Buffer
+	content.Write(existing)
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		content.WriteString("\n")
+	}
+	content.Write(separator)
+	content.Write(rendered)
+	return content.Bytes()
+}
+
+func mergeSummary(existing []byte, rendered []byte, format string) ([]byte, error) {
+	switch format {
+	case FormatStandup:
+		// A standup is rendered from the whole day's data, the latest one covers the earlier ones
+		return rendered, nil
+	case FormatJSON, FormatYAML:
+		var previous, current DailyExport
+		if err := unmarshalExport(existing, format, &previous); err != nil {
+			return nil, fmt.Errorf("failed to read existing summary for merging: %w", err)
+		}
+		if err := unmarshalExport(rendered, format, &current); err != nil {
+			return nil, err
+		}
+		return marshalExport(MergeExports(previous, current), format)
+	}
+	return []byte(MergeMarkdown(string(existing), string(rendered))), nil
+}
+
+func unmarshalExport(content []byte, format string, export *DailyExport) error {
+	if format == FormatJSON {
+		return json.Unmarshal(content, export)
+	}
+	return yaml.Unmarshal(content, export)
+}
+
+func marshalExport(export DailyExport, format string) ([]byte, error) {
+	var out bytes.Buffer
+	if format == FormatJSON {
+		encoder := json.NewEncoder(&out)
+		encoder.SetIndent("", "  ")
+		err := encoder.Encode(export)
+		return out.Bytes(), err
+	}
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(export); err != nil {
+		return nil, err
+	}
+	err := encoder.Close()
+	return out.Bytes(), err
+}
+
+// MergeExports combines two exports of the same day. Items are matched by URL or identifier,
+// notes are combined line by line, and the window grows to cover both runs.
+func MergeExports(previous DailyExport, current DailyExport) DailyExport {
+	merged := current
+
+	if !previous.Window.Since.IsZero() && previous.Window.Since.Before(merged.Window.Since) {
+		merged.Window.Since = previous.Window.Since
+	}
+
+	// Items left out in either run stay out, notes of the others are combined
+	excluded := make(map[string]bool)
+	merged.GitHub.Excluded = nil
+	for _, url := range append(append([]string{}, previous.GitHub.Excluded...), current.GitHub.Excluded...) {
+		if !excluded[url] {
+			excluded[url] = true
+			merged.GitHub.Excluded = append(merged.GitHub.Excluded, url)
+		}
+	}
+
+	merged.GitHub.PullRequests = mergePullRequests(previous.GitHub.PullRequests, current.GitHub.PullRequests, excluded)
+	merged.GitHub.Reviews = mergePullRequests(previous.GitHub.Reviews, current.GitHub.Reviews, excluded)
+	merged.GitHub.Issues = []ExportGitHubIssue{}
+	previousIssueNotes := make(map[string]string)
+	for _, issue := range previous.GitHub.Issues {
+		previousIssueNotes[issue.URL] = issue.Notes
+	}
+	for _, issue := range mergeByKey(previous.GitHub.Issues, current.GitHub.Issues, func(issue ExportGitHubIssue) string { return issue.URL }) {
+		if !excluded[issue.URL] {
+			issue.Notes = MergeNotes(previousIssueNotes[issue.URL], issue.Notes)
+			merged.GitHub.Issues = append(merged.GitHub.Issues, issue)
+		}
+	}
+
+	commits := make(map[string]int)
+	for _, repo := range append(append([]ExportRepoCommits{}, previous.GitHub.Commits...), current.GitHub.Commits...) {
+		if repo.Commits > commits[repo.Repo] {
+			commits[repo.Repo] = repo.Commits
+		}
+	}
+	merged.GitHub.Commits = []ExportRepoCommits{}
+	for _, repo := range sortedKeys(commits) {
+		merged.GitHub.Commits = append(merged.GitHub.Commits, ExportRepoCommits{Repo: repo, Commits: commits[repo]})
+	}
+
+	merged.GitHub.CommitHeadlines = mergeByKey(previous.GitHub.CommitHeadlines, current.GitHub.CommitHeadlines, func(commit ExportCommit) string {
+		return commit.URL
+	})
+
+	merged.GitHub.Totals = ExportGitHubTotals{
+		Commits:      maxInt(previous.GitHub.Totals.Commits, current.GitHub.Totals.Commits),
+		PullRequests: maxInt(previous.GitHub.Totals.PullRequests, current.GitHub.Totals.PullRequests),
+		Reviews:      maxInt(previous.GitHub.Totals.Reviews, current.GitHub.Totals.Reviews),
+		Issues:       maxInt(previous.GitHub.Totals.Issues, current.GitHub.Totals.Issues),
+	}
+	if merged.GitHub.Username == "" {
+		merged.GitHub.Username = previous.GitHub.Username
+	}
+
+	merged.Issues = append([]ExportIssue{}, previous.Issues...)
+	for _, issue := range current.Issues {
+		found := false
+		for i := range merged.Issues {
+			if merged.Issues[i].Identifier == issue.Identifier {
+				notes := MergeNotes(merged.Issues[i].Notes, issue.Notes)
+				if issue.Minutes == 0 {
+					issue.Minutes = merged.Issues[i].Minutes
+				}
+				merged.Issues[i] = issue
+				merged.Issues[i].Notes = notes
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged.Issues = append(merged.Issues, issue)
+		}
+	}
+
+	merged.Entries = mergeByKey(previous.Entries, current.Entries, func(entry ExportEntry) string {
+		return entry.entry().key()
+	})
+	if len(merged.Entries) == 0 {
+		merged.Entries = nil
+	}
+
+	merged.Plan = mergeByKey(previous.Plan, current.Plan, func(item ExportPlanItem) string {
+		return PlanItem(item).key()
+	})
+	if len(merged.Plan) == 0 {
+		merged.Plan = nil
+	}
+	merged.Blockers = mergeByKey(previous.Blockers, current.Blockers, func(blocker ExportBlocker) string {
+		return Blocker(blocker).key()
+	})
+	if len(merged.Blockers) == 0 {
+		merged.Blockers = nil
+	}
+
+	// Runs of the same day estimate the same activity, the later one seeing more of it
+	merged.ActiveTime = mergeActiveTime(previous.ActiveTime, current.ActiveTime, maxInt)
+
+	merged.Timeline = mergeByKey(previous.Timeline, current.Timeline, func(event ExportTimelineEvent) string {
+		return TimelineEvent(event).key()
+	})
+	sort.SliceStable(merged.Timeline, func(i, j int) bool { return merged.Timeline[i].At.Before(merged.Timeline[j].At) })
+	if len(merged.Timeline) == 0 {
+		merged.Timeline = nil
+	}
+
+	// An issue stays "not worked" only if no run recorded work on it
+	worked := make(map[string]bool)
+	for _, issue := range merged.Issues {
+		worked[issue.Identifier] = true
+	}
+	merged.NotWorked = nil
+	for _, issue := range mergeByKey(previous.NotWorked, current.NotWorked, func(issue ExportIssue) string { return issue.Identifier }) {
+		if !worked[issue.Identifier] {
+			merged.NotWorked = append(merged.NotWorked, issue)
+		}
+	}
+
+	return merged
+}
+
+// NewSince leaves out of current what an earlier run of the same day already recorded, so
+// appending it to that run's summary only adds what is new: the items the earlier run did
+// not have, the note lines and time added since on those it had, and the commits made since.
+// The active time covers the whole day and is left to the earlier run's summary.
+func NewSince(previous DailyExport, current DailyExport) DailyExport {
+	fresh := current
+	fresh.ActiveTime = nil
+
+	fresh.GitHub.PullRequests = newPullRequests(previous.GitHub.PullRequests, current.GitHub.PullRequests)
+	fresh.GitHub.Reviews = newPullRequests(previous.GitHub.Reviews, current.GitHub.Reviews)
+	previousIssueNotes := make(map[string]*string)
+	for i := range previous.GitHub.Issues {
+		previousIssueNotes[previous.GitHub.Issues[i].URL] = &previous.GitHub.Issues[i].Notes
+	}
+	fresh.GitHub.Issues = []ExportGitHubIssue{}
+	for _, issue := range current.GitHub.Issues {
+		if notes, ok := previousIssueNotes[issue.URL]; ok {
+			if issue.Notes = newNoteLines(*notes, issue.Notes); issue.Notes == "" {
+				continue
+			}
+		}
+		fresh.GitHub.Issues = append(fresh.GitHub.Issues, issue)
+	}
+
+	previousCommits := make(map[string]int)
+	for _, repo := range previous.GitHub.Commits {
+		previousCommits[repo.Repo] = repo.Commits
+	}
+	fresh.GitHub.Commits = []ExportRepoCommits{}
+	for _, repo := range current.GitHub.Commits {
+		if repo.Commits -= previousCommits[repo.Repo]; repo.Commits > 0 {
+			fresh.GitHub.Commits = append(fresh.GitHub.Commits, repo)
+		}
+	}
+	fresh.GitHub.CommitHeadlines = newByKey(previous.GitHub.CommitHeadlines, current.GitHub.CommitHeadlines, func(commit ExportCommit) string {
+		return commit.URL
+	})
+	fresh.GitHub.Totals = ExportGitHubTotals{
+		Commits:      maxInt(current.GitHub.Totals.Commits-previous.GitHub.Totals.Commits, 0),
+		PullRequests: len(fresh.GitHub.PullRequests),
+		Reviews:      len(fresh.GitHub.Reviews),
+		Issues:       len(fresh.GitHub.Issues),
+	}
+
+	previousIssues := make(map[string]ExportIssue)
+	for _, issue := range previous.Issues {
+		previousIssues[issue.Identifier] = issue
+	}
+	fresh.Issues = []ExportIssue{}
+	for _, issue := range current.Issues {
+		if earlier, ok := previousIssues[issue.Identifier]; ok {
+			issue.Notes = newNoteLines(earlier.Notes, issue.Notes)
+			issue.Minutes = maxInt(issue.Minutes-earlier.Minutes, 0)
+			if issue.Notes == "" && issue.Minutes == 0 {
+				continue
+			}
+		}
+		fresh.Issues = append(fresh.Issues, issue)
+	}
+	fresh.NotWorked = newByKey(previous.NotWorked, current.NotWorked, func(issue ExportIssue) string { return issue.Identifier })
+
+	fresh.Entries = newByKey(previous.Entries, current.Entries, func(entry ExportEntry) string {
+		return entry.entry().key()
+	})
+	fresh.Plan = newByKey(previous.Plan, current.Plan, func(item ExportPlanItem) string {
+		return PlanItem(item).key()
+	})
+	fresh.Blockers = newByKey(previous.Blockers, current.Blockers, func(blocker ExportBlocker) string {
+		return Blocker(blocker).key()
+	})
+	fresh.Timeline = newByKey(previous.Timeline, current.Timeline, func(event ExportTimelineEvent) string {
+		return TimelineEvent(event).key()
+	})
+	return fresh
+}
+
+// newPullRequests returns the pull requests of current that previous did not have, and those
+// it had with only the note lines added since
+func newPullRequests(previous []ExportPullRequest, current []ExportPullRequest) []ExportPullRequest {
+	previousNotes := make(map[string]*string)
+	for i := range previous {
+		previousNotes[previous[i].URL] = &previous[i].Notes
+	}
+
+	fresh := []ExportPullRequest{}
+	for _, pr := range current {
+		if notes, ok := previousNotes[pr.URL]; ok {
+			if pr.Notes = newNoteLines(*notes, pr.Notes); pr.Notes == "" {
+				continue
+			}
+		}
+		fresh = append(fresh, pr)
+	}
+	return fresh
+}
+
+// newNoteLines returns the lines of current that are not part of previous
+func newNoteLines(previous string, current string) string {
+	seen := make(map[string]bool)
+	for _, line := range noteLines(previous) {
+		seen[line] = true
+	}
+
+	var lines []string
+	for _, line := range noteLines(current) {
+		if !seen[line] {
+			seen[line] = true
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// newByKey returns the items of current whose key is not in previous, nil when there are none
+func newByKey[T any](previous []T, current []T, key func(T) string) []T {
+	seen := make(map[string]bool)
+	for _, item := range previous {
+		seen[key(item)] = true
+	}
+
+	var fresh []T
+	for _, item := range current {
+		if !seen[key(item)] {
+			fresh = append(fresh, item)
+		}
+	}
+	return fresh
+}
+
+// mergeActiveTime combines two estimates of active time, total by total and name by name
+func mergeActiveTime(previous *ExportActiveTime, current *ExportActiveTime, combine func(a int, b int) int) *ExportActiveTime {
+	if previous == nil || current == nil {
+		if previous != nil {
+			return previous
+		}
+		return current
+	}
+
+	combineEstimates := func(previous []ExportEstimate, current []ExportEstimate) []ExportEstimate {
+		minutes := make(map[string]int)
+		for _, estimate := range previous {
+			minutes[estimate.Name] = estimate.Minutes
+		}
+		for _, estimate := range current {
+			minutes[estimate.Name] = combine(minutes[estimate.Name], estimate.Minutes)
+		}
+
+		var estimates []TimeEstimate
+		for name, total := range minutes {
+			estimates = append(estimates, TimeEstimate{Name: name, Spent: time.Duration(total) * time.Minute})
+		}
+		sortEstimates(estimates)
+		return exportEstimates(estimates)
+	}
+
+	return &ExportActiveTime{
+		Total:  combine(previous.Total, current.Total),
+		Repos:  combineEstimates(previous.Repos, current.Repos),
+		Issues: combineEstimates(previous.Issues, current.Issues),
+	}
+}
+
+// MergeNotes appends the lines of current that are not already part of previous
+func MergeNotes(previous string, current string) string {
+	if previous == "" {
+		return current
+	}
+
+	seen := make(map[string]bool)
+	for _, line := range noteLines(previous) {
+		seen[line] = true
+	}
+
+	merged := strings.TrimRight(previous, "\n")
+	for _, line := range noteLines(current) {
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		if merged != "" {
+			merged += "\n"
+		}
+		merged += line
+	}
+	return merged
+}
+
+func mergeByKey[T any](previous []T, current []T, key func(T) string) []T {
+	merged := []T{}
+	index := make(map[string]int)
+	for _, item := range append(append([]T{}, previous...), current...) {
+		if i, ok := index[key(item)]; ok {
+			merged[i] = item
+			continue
+		}
+		index[key(item)] = len(merged)
+		merged = append(merged, item)
+	}
+	return merged
+}
+
+// mergePullRequests combines the pull requests of two runs, leaving out excluded ones
+func mergePullRequests(previous []ExportPullRequest, current []ExportPullRequest, excluded map[string]bool) []ExportPullRequest {
+	previousNotes := make(map[string]string)
+	for _, pr := range previous {
+		previousNotes[pr.URL] = pr.Notes
+	}
+
+	merged := []ExportPullRequest{}
+	for _, pr := range mergeByKey(previous, current, func(pr ExportPullRequest) string { return pr.URL }) {
+		if !excluded[pr.URL] {
+			pr.Notes = MergeNotes(previousNotes[pr.URL], pr.Notes)
+			merged = append(merged, pr)
+		}
+	}
+	return merged
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// markdownSection is a "## " heading and the blocks below it. A block is a line
+// together with the indented lines that follow it, e.g. an issue and its notes.
+type markdownSection struct {
+	heading string
+	blocks  [][]string
+}
+
+// MergeMarkdown merges a newly rendered summary into an existing one. Sections are matched
+// by heading and blocks by blockKey: a rendered block replaces the existing one with the
+// same key, keeping the notes typed below it, and blocks typed by hand are kept.
+func MergeMarkdown(existing string, rendered string) string {
+	existingHeader, existingSections := parseMarkdownSections(existing)
+	_, renderedSections := parseMarkdownSections(rendered)
+
+	merged := existingSections
+	for _, section := range renderedSections {
+		index := -1
+		for i := range merged {
+			if merged[i].heading == section.heading {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			merged = append(merged, section)
+			continue
+		}
+		merged[index].blocks = mergeBlocks(merged[index].blocks, section.blocks)
+	}
+
+	var out strings.Builder
+	for _, line := range existingHeader {
+		// Drop the empty-day placeholder once there is something to show
+		if line == "No activity recorded for this period." && len(merged) > 0 {
+			continue
+		}
+		out.WriteString(line + "\n")
+	}
+	for _, section := range merged {
+		if out.Len() > 0 && !strings.HasSuffix(out.String(), "\n\n") {
+			out.WriteString("\n")
+		}
+		out.WriteString(section.heading + "\n\n")
+		for i, block := range section.blocks {
+			// A paragraph and a list are kept apart by a blank line
+			if i > 0 && isListItem(section.blocks[i-1]) != isListItem(block) {
+				out.WriteString("\n")
+			}
+			for _, line := range block {
+				out.WriteString(line + "\n")
+			}
+		}
+	}
+	return strings.TrimRight(out.String(), "\n") + "\n"
+}
+
+func isListItem(block []string) bool {
+	return len(block) > 0 && strings.HasPrefix(block[0], "- ")
+}
+
+func parseMarkdownSections(content string) ([]string, []markdownSection) {
+	var header []string
+	var sections []markdownSection
+
+	for _, line := range strings.Split(strings.TrimRight(content, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "## "):
+			sections = append(sections, markdownSection{heading: line})
+		case len(sections) == 0:
+			header = append(header, line)
+		case strings.TrimSpace(line) == "":
+			// Blank lines are re-created when writing
+		case strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t"):
+			current := &sections[len(sections)-1]
+			if len(current.blocks) == 0 {
+				current.blocks = append(current.blocks, []string{})
+			}
+			current.blocks[len(current.blocks)-1] = append(current.blocks[len(current.blocks)-1], line)
+		default:
+			current := &sections[len(sections)-1]
+			current.blocks = append(current.blocks, []string{line})
+		}
+	}
+
+	// Trim trailing blank lines of the header, they are re-created when writing
+	for len(header) > 0 && strings.TrimSpace(header[len(header)-1]) == "" {
+		header = header[:len(header)-1]
+	}
+	if len(header) > 0 {
+		header = append(header, "")
+	}
+	return header, sections
+}
+
+func mergeBlocks(existing [][]string, rendered [][]string) [][]string {
+	merged := existing
+	matched := make(map[int]bool)
+	for _, block := range rendered {
+		if len(block) == 0 {
+			continue
+		}
+
+		index := -1
+		for i := range merged {
+			if !matched[i] && len(merged[i]) > 0 && blockKey(merged[i][0]) == blockKey(block[0]) {
+				index = i
+				break
+			}
+		}
+		if index == -1 {
+			matched[len(merged)] = true
+			merged = append(merged, block)
+			continue
+		}
+		matched[index] = true
+
+		// The rendered block is up to date, the lines typed below the existing one are kept
+		replaced := append([]string{}, block...)
+		seen := make(map[string]bool)
+		for _, line := range block {
+			seen[strings.TrimSpace(line)] = true
+		}
+		for _, line := range merged[index][1:] {
+			if !seen[strings.TrimSpace(line)] {
+				replaced = append(replaced, line)
+				seen[strings.TrimSpace(line)] = true
+			}
+		}
+		merged[index] = replaced
+	}
+	return merged
+}
+
+// markdownLinkPattern matches the first link of a line, e.g. "[ENG-1: Title](https://...)"
+var markdownLinkPattern = regexp.MustCompile(`\[[^\]]*\]\(([^)]+)\)`)
+
+// markdownNumberPattern matches the counts and durations that change between two renders,
+// e.g. "3", "1h", "2h30" or "45m"
+var markdownNumberPattern = regexp.MustCompile(`\d[\d.:]*(?:h\d*m?|m)?`)
+
+// blockKey is what identifies a block between two renders of the same day. A line with a
+// link is keyed by what comes before the link and its URL, so a new title or time spent
+// still matches. Other lines are keyed with their numbers left out, so
+// "- acme/api: 3 commit(s)" matches "- acme/api: 5 commit(s)".
+func blockKey(line string) string {
+	if match := markdownLinkPattern.FindStringSubmatchIndex(line); match != nil {
+		return line[:match[0]] + line[match[2]:match[3]]
+	}
+	return markdownNumberPattern.ReplaceAllString(line, "#")
+}
+
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
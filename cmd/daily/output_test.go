@@ -0,0 +1,265 @@
+package daily
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the output path honours stdout, explicit paths, directories and output.dir
+func Test_ResolveOutputPath(t *testing.T) {
+	date := time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+
+	path, err := ResolveOutputPath("-", "", date, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, StdoutPath, path)
+
+	path, err = ResolveOutputPath("notes.md", "", date, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, "notes.md", path)
+
+	path, err = ResolveOutputPath(dir, "", date, FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "daily-summary-2025-10-22.json"), path)
+
+	path, err = ResolveOutputPath("", filepath.Join(dir, "summaries"), date, FormatMarkdown)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "summaries", "daily-summary-2025-10-22.md"), path)
+	assert.DirExists(t, filepath.Join(dir, "summaries"))
+}
+
+// Test an existing summary is not overwritten unless asked to
+func Test_WriteSummaryOutput_RefusesOverwrite(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("morning notes\n"), 0o644))
+
+	// Act
+	err := WriteSummaryOutput(sampleSummaryData(), FormatMarkdown, "", path, ExistingAsk)
+
+	// Assert
+	require.Error(t, err)
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "morning notes\n", string(content))
+
+	require.NoError(t, WriteSummaryOutput(sampleSummaryData(), FormatMarkdown, "", path, ExistingOverwrite))
+	content, _ = os.ReadFile(path)
+	assert.True(t, strings.HasPrefix(string(content), "# Daily Work Summary"))
+}
+
+// Test a summary only counts as unchanged while it is exactly what was last written
+func Test_UnchangedSinceRender(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("morning notes\n"), 0o644))
+	assert.False(t, UnchangedSinceRender(dataDir, path), "a file never written by mastercrab")
+
+	// Act
+	require.NoError(t, WriteSummaryOutput(sampleSummaryData(), FormatMarkdown, "", path, ExistingOverwrite))
+	require.NoError(t, RecordRender(dataDir, path))
+
+	// Assert
+	assert.True(t, UnchangedSinceRender(dataDir, path))
+
+	content, _ := os.ReadFile(path)
+	require.NoError(t, os.WriteFile(path, append(content, []byte("- hand edit\n")...), 0o644))
+	assert.False(t, UnchangedSinceRender(dataDir, path), "a file edited since")
+}
+
+// Test appending keeps the existing summary above the new one
+func Test_WriteSummaryOutput_Append(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("morning notes\n"), 0o644))
+
+	// Act
+	err := WriteSummaryOutput(sampleSummaryData(), FormatMarkdown, "", path, ExistingAppend)
+
+	// Assert
+	require.NoError(t, err)
+	content, _ := os.ReadFile(path)
+	assert.True(t, strings.HasPrefix(string(content), "morning notes\n\n---\n\n# Daily Work Summary"))
+
+	assert.Error(t, WriteSummaryOutput(sampleSummaryData(), FormatJSON, "", path, ExistingAppend))
+}
+
+// Test merging markdown adds new bullets and notes without duplicating existing ones
+func Test_MergeMarkdown(t *testing.T) {
+	// Arrange
+	existing := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR](https://github.com/o/r/pull/1)
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1)
+  - Morning work
+`
+	rendered := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR](https://github.com/o/r/pull/1)
+- [o/r#2: Second PR](https://github.com/o/r/pull/2)
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1)
+  - Morning work
+  - Afternoon work
+- [TEST-2: Billing](https://linear.app/t/TEST-2)
+`
+
+	// Act
+	merged := MergeMarkdown(existing, rendered)
+
+	// Assert
+	expected := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR](https://github.com/o/r/pull/1)
+- [o/r#2: Second PR](https://github.com/o/r/pull/2)
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1)
+  - Morning work
+  - Afternoon work
+- [TEST-2: Billing](https://linear.app/t/TEST-2)
+`
+	assert.Equal(t, expected, merged)
+	assert.Equal(t, expected, MergeMarkdown(merged, rendered), "merging twice changes nothing")
+}
+
+// Test merging replaces the lines whose counts or titles changed instead of repeating them
+func Test_MergeMarkdown_ChangedCounts(t *testing.T) {
+	// Arrange
+	existing := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR](https://github.com/o/r/pull/1)
+- o/r: 3 commit(s)
+- Paired with Sam on the release
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1) (1h)
+  - Morning work
+
+## Estimated Active Time
+
+About 1h of activity in total.
+
+- o/r: ~1h
+`
+	rendered := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR, renamed](https://github.com/o/r/pull/1)
+- o/r: 5 commit(s)
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1) (2h30)
+  - Afternoon work
+
+## Estimated Active Time
+
+About 2h30 of activity in total.
+
+- o/r: ~2h30
+`
+
+	// Act
+	merged := MergeMarkdown(existing, rendered)
+
+	// Assert
+	expected := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [o/r#1: First PR, renamed](https://github.com/o/r/pull/1)
+- o/r: 5 commit(s)
+- Paired with Sam on the release
+
+## Linear Issues
+
+- [TEST-1: Auth](https://linear.app/t/TEST-1) (2h30)
+  - Afternoon work
+  - Morning work
+
+## Estimated Active Time
+
+About 2h30 of activity in total.
+
+- o/r: ~2h30
+`
+	assert.Equal(t, expected, merged)
+	assert.Equal(t, expected, MergeMarkdown(merged, rendered), "merging twice changes nothing")
+}
+
+// Test merging structured output unions items and notes
+func Test_WriteSummaryOutput_MergeJSON(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "summary.json")
+	morning := sampleSummaryData()
+	morning.Issues[0].UserNotes = "Added token refresh"
+	require.NoError(t, WriteSummaryOutput(morning, FormatJSON, "", path, ExistingOverwrite))
+
+	evening := sampleSummaryData()
+	evening.GitHub.PullRequestsCreated[0].URL = "https://github.com/testorg/mastercrab/pull/11"
+	evening.Issues[0].UserNotes = "Added token refresh\nFixed review comments"
+
+	// Act
+	err := WriteSummaryOutput(evening, FormatJSON, "", path, ExistingMerge)
+
+	// Assert
+	require.NoError(t, err)
+	content, _ := os.ReadFile(path)
+	var merged DailyExport
+	require.NoError(t, json.Unmarshal(content, &merged))
+	assert.Len(t, merged.GitHub.PullRequests, 2)
+	require.Len(t, merged.Issues, 1)
+	assert.Equal(t, "Added token refresh\nFixed review comments", merged.Issues[0].Notes)
+}
+
+// Test notes are merged line by line
+func Test_MergeNotes(t *testing.T) {
+	assert.Equal(t, "a\nb\nc", MergeNotes("a\nb", "b\nc"))
+	assert.Equal(t, "new", MergeNotes("", "new"))
+	assert.Equal(t, "old", MergeNotes("old", ""))
+}
+
+// Test an existing file is left as it was when the write is cancelled, and replaced on overwrite
+func Test_WriteRenderedOutput(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "brag.md")
+	require.NoError(t, os.WriteFile(path, []byte("# Edited by hand\n"), 0o644))
+
+	// Act
+	cancelErr := WriteRenderedOutput([]byte("# Brag\n"), FormatMarkdown, path, ExistingCancel)
+	cancelled, _ := os.ReadFile(path)
+	overwriteErr := WriteRenderedOutput([]byte("# Brag\n"), FormatMarkdown, path, ExistingOverwrite)
+	overwritten, _ := os.ReadFile(path)
+
+	// Assert
+	require.Error(t, cancelErr)
+	assert.Equal(t, "# Edited by hand\n", string(cancelled))
+	require.NoError(t, overwriteErr)
+	assert.Equal(t, "# Brag\n", string(overwritten))
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	assert.Len(t, entries, 1, "no temporary file is left behind")
+}
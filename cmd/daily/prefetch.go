@@ -0,0 +1,80 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultPrefetch is how many issues ahead details are fetched when "linear.prefetch" is not set
+const DefaultPrefetch = 3
+
+// IssueDetailsFetcher fetches the details of a single Linear issue
+type IssueDetailsFetcher func(ctx context.Context, issueID string) (LinearIssueDetails, error)
+
+// PrefetchedIssue is the outcome of fetching one issue's details
+type PrefetchedIssue struct {
+	Item    ActivityItem
+	Details LinearIssueDetails
+	Err     error
+}
+
+// PrefetchIssueDetails fetches issue details in the background while the caller works through them.
+// At most concurrency issues are in flight or waiting to be consumed at any time, and results are
+// delivered in the original order. The channel is closed once every issue was delivered or ctx is done.
+func PrefetchIssueDetails(ctx context.Context, issues []ActivityItem, concurrency int, fetch IssueDetailsFetcher) <-chan PrefetchedIssue {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	out := make(chan PrefetchedIssue)
+	slots := make(chan struct{}, concurrency)
+	results := make([]chan PrefetchedIssue, len(issues))
+	for i := range results {
+		results[i] = make(chan PrefetchedIssue, 1)
+	}
+
+	// Dispatcher: start a fetch whenever a slot is free
+	go func() {
+		for i, issue := range issues {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(i int, issue ActivityItem) {
+				// A panicking fetch must not take the typed notes down with it
+				defer func() {
+					if r := recover(); r != nil {
+						results[i] <- PrefetchedIssue{Item: issue, Err: fmt.Errorf("fetching details panicked: %v", r)}
+					}
+				}()
+
+				details, err := fetch(ctx, issue.ID)
+				results[i] <- PrefetchedIssue{Item: issue, Details: details, Err: err}
+			}(i, issue)
+		}
+	}()
+
+	// Emitter: hand results over in order, freeing a slot once each one is consumed
+	go func() {
+		defer close(out)
+		for i := range issues {
+			var result PrefetchedIssue
+			select {
+			case result = <-results[i]:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case out <- result:
+				<-slots
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
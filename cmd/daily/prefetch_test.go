@@ -0,0 +1,114 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func issueItems(ids ...string) []ActivityItem {
+	items := make([]ActivityItem, len(ids))
+	for i, id := range ids {
+		items[i] = ActivityItem{Source: "linear", Kind: ItemKindLinearIssue, ID: id}
+	}
+	return items
+}
+
+// Test details are delivered in the original order even when fetches finish out of order
+func Test_PrefetchIssueDetails_KeepsOrder(t *testing.T) {
+	// Arrange
+	delays := map[string]time.Duration{"a": 60 * time.Millisecond, "b": 5 * time.Millisecond, "c": 30 * time.Millisecond}
+	fetch := func(ctx context.Context, id string) (LinearIssueDetails, error) {
+		time.Sleep(delays[id])
+		if id == "c" {
+			return LinearIssueDetails{}, fmt.Errorf("not found")
+		}
+		return LinearIssueDetails{ID: id, Identifier: "TEST-" + id}, nil
+	}
+
+	// Act
+	var got []PrefetchedIssue
+	for result := range PrefetchIssueDetails(context.Background(), issueItems("a", "b", "c"), 3, fetch) {
+		got = append(got, result)
+	}
+
+	// Assert
+	require.Len(t, got, 3)
+	assert.Equal(t, "TEST-a", got[0].Details.Identifier)
+	assert.Equal(t, "TEST-b", got[1].Details.Identifier)
+	assert.Equal(t, "c", got[2].Item.ID)
+	assert.EqualError(t, got[2].Err, "not found")
+}
+
+// Test no more than the configured number of issues are fetched ahead of the consumer
+func Test_PrefetchIssueDetails_BoundedConcurrency(t *testing.T) {
+	// Arrange
+	var mu sync.Mutex
+	started := 0
+	fetch := func(ctx context.Context, id string) (LinearIssueDetails, error) {
+		mu.Lock()
+		started++
+		mu.Unlock()
+		return LinearIssueDetails{ID: id}, nil
+	}
+
+	// Act - consume one result, then give the pipeline time to run ahead
+	results := PrefetchIssueDetails(context.Background(), issueItems("1", "2", "3", "4", "5", "6"), 2, fetch)
+	first := <-results
+	time.Sleep(50 * time.Millisecond)
+
+	// Assert
+	assert.Equal(t, "1", first.Details.ID)
+	mu.Lock()
+	assert.Equal(t, 3, started, "one consumed issue plus two prefetched")
+	mu.Unlock()
+
+	remaining := 0
+	for range results {
+		remaining++
+	}
+	assert.Equal(t, 5, remaining)
+}
+
+// Test the pipeline stops when the context is cancelled
+func Test_PrefetchIssueDetails_Cancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	fetch := func(ctx context.Context, id string) (LinearIssueDetails, error) {
+		<-ctx.Done()
+		return LinearIssueDetails{}, ctx.Err()
+	}
+	results := PrefetchIssueDetails(ctx, issueItems("a", "b"), 1, fetch)
+
+	// Act
+	cancel()
+
+	// Assert
+	select {
+	case _, ok := <-results:
+		if ok {
+			// A result may race the cancellation, but the channel must still close
+			for range results {
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("pipeline did not stop after cancellation")
+	}
+}
+
+// Test a panicking detail fetch becomes an error instead of crashing the run
+func Test_PrefetchIssueDetails_RecoversPanic(t *testing.T) {
+	fetch := func(ctx context.Context, id string) (LinearIssueDetails, error) {
+		panic("nil map")
+	}
+
+	result := <-PrefetchIssueDetails(context.Background(), issueItems("a"), 1, fetch)
+
+	require.Error(t, result.Err)
+	assert.Contains(t, result.Err.Error(), "panicked: nil map")
+}
@@ -0,0 +1,402 @@
+package daily
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// ReplayToken stands in for the API keys of sources when replaying, so they count as configured
+const ReplayToken = "replay"
+
+// redacted replaces API keys in recorded exchanges
+const redacted = "[REDACTED]"
+
+// DefaultLinearURL is Linear's GraphQL endpoint, used when replaying without a configured "linear.baseURL"
+const DefaultLinearURL = "https://api.linear.app/graphql"
+
+// Exchange is a recorded request and the response it got, saved as one JSON file
+type Exchange struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request of an Exchange, with its API keys redacted
+type RecordedRequest struct {
+	Method  string            `json:"method"`
+	URL     string            `json:"url"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body,omitempty"`
+}
+
+// RecordedResponse is the response of an Exchange
+type RecordedResponse struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    json.RawMessage   `json:"body"`
+}
+
+// ConfigureReplay checks the "http.record" and "http.replay" config keys, set by --record
+// and --replay. When replaying, sources without an API key get ReplayToken so they are
+// fetched from the recording, and "data.dir" and "output.dir" point to a new temporary
+// directory so the recorded activity never reaches your own history, session or summaries.
+func ConfigureReplay(config *viper.Viper) error {
+	replay := config.GetString("http.replay")
+	if replay == "" {
+		return nil
+	}
+	if config.GetString("http.record") != "" {
+		return fmt.Errorf("--record and --replay cannot be used together")
+	}
+	if info, err := os.Stat(replay); err != nil || !info.IsDir() {
+		return fmt.Errorf("no recording to replay in %s", replay)
+	}
+
+	dataDir, err := os.MkdirTemp("", "mastercrab-replay-")
+	if err != nil {
+		return fmt.Errorf("failed to create a data directory for the replay: %w", err)
+	}
+	config.Set("data.dir", dataDir)
+	config.Set("output.dir", dataDir)
+	fmt.Fprintf(os.Stderr, "▶️  Replaying %s, data and summaries go to %s\n", replay, dataDir)
+
+	config.SetDefault("linear.apiToken", ReplayToken)
+	config.SetDefault("linear.baseURL", DefaultLinearURL)
+	config.SetDefault("github.apiToken", ReplayToken)
+	return nil
+}
+
+// recordingTransport sends requests with next and saves every exchange to dir, numbered
+// in the order responses arrive. The secrets are redacted wherever they appear.
+type recordingTransport struct {
+	dir     string
+	secrets []string
+	next    http.RoundTripper
+
+	mu   sync.Mutex
+	seq  int
+	init bool
+}
+
+// NewRecordingTransport records the exchanges made through next in dir, redacting the
+// Authorization header and the secrets
+func NewRecordingTransport(dir string, secrets []string, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	var kept []string
+	for _, secret := range secrets {
+		if secret != "" {
+			kept = append(kept, secret)
+		}
+	}
+	return &recordingTransport{dir: dir, secrets: kept, next: next}
+}
+
+func (t *recordingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var requestBody []byte
+	if request.Body != nil {
+		var err error
+		requestBody, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(requestBody))
+	}
+
+	response, err := t.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	responseBody, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(responseBody))
+
+	exchange := Exchange{
+		Request: RecordedRequest{
+			Method:  request.Method,
+			URL:     t.redact(request.URL.String()),
+			Headers: t.headers(request.Header),
+			Body:    rawBody([]byte(t.redact(string(requestBody)))),
+		},
+		Response: RecordedResponse{
+			Status:  response.StatusCode,
+			Headers: t.headers(response.Header),
+			Body:    rawBody([]byte(t.redact(string(responseBody)))),
+		},
+	}
+	if err := t.save(exchange, operationName(requestBody)); err != nil {
+		return nil, fmt.Errorf("failed to record %s: %w", request.URL, err)
+	}
+	return response, nil
+}
+
+func (t *recordingTransport) redact(value string) string {
+	for _, secret := range t.secrets {
+		value = strings.ReplaceAll(value, secret, redacted)
+	}
+	return value
+}
+
+// headers keeps the first value of each header, with credentials redacted
+func (t *recordingTransport) headers(header http.Header) map[string]string {
+	if len(header) == 0 {
+		return nil
+	}
+	kept := make(map[string]string)
+	for name := range header {
+		value := header.Get(name)
+		switch strings.ToLower(name) {
+		case "authorization", "cookie", "set-cookie":
+			value = redacted
+		}
+		kept[name] = t.redact(value)
+	}
+	return kept
+}
+
+var unsafeFilename = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// save writes the exchange as the next numbered file, after the ones already in dir
+func (t *recordingTransport) save(exchange Exchange, name string) error {
+	content, err := json.MarshalIndent(exchange, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.init {
+		if err := os.MkdirAll(t.dir, 0o755); err != nil {
+			return err
+		}
+		existing, _ := filepath.Glob(filepath.Join(t.dir, "*.json"))
+		t.seq = len(existing)
+		t.init = true
+	}
+
+	name = unsafeFilename.ReplaceAllString(name, "-")
+	if name == "" {
+		name = "request"
+	}
+	for {
+		t.seq++
+		path := filepath.Join(t.dir, fmt.Sprintf("%04d-%s.json", t.seq, name))
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		_, err = file.Write(append(content, '\n'))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		return err
+	}
+}
+
+// replayTransport answers requests with the exchanges recorded in dir, without any network.
+// A request gets the first unused exchange with the same body, then the first unused one
+// that only differs by its dates and times, which change from run to run, and finally the
+// last exchange served for it again. Requests for anything else, such as another issue,
+// fail rather than get another request's response.
+type replayTransport struct {
+	dir string
+
+	load      sync.Once
+	loadErr   error
+	mu        sync.Mutex
+	exchanges []Exchange
+	used      []bool
+	last      map[string]int
+}
+
+// NewReplayTransport serves the exchanges recorded in dir with NewRecordingTransport
+func NewReplayTransport(dir string) http.RoundTripper {
+	return &replayTransport{dir: dir, last: make(map[string]int)}
+}
+
+func (t *replayTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.load.Do(func() { t.loadErr = t.loadExchanges() })
+	if t.loadErr != nil {
+		return nil, t.loadErr
+	}
+
+	exchange, ok := t.match(body)
+	if !ok {
+		operation := operationName(body)
+		if operation == "" {
+			operation = request.Method + " " + request.URL.String()
+		}
+		return nil, fmt.Errorf("no recorded response for %s in %s", operation, t.dir)
+	}
+
+	header := make(http.Header)
+	for name, value := range exchange.Response.Headers {
+		header.Set(name, value)
+	}
+	content := bodyBytes(exchange.Response.Body)
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", exchange.Response.Status, http.StatusText(exchange.Response.Status)),
+		StatusCode:    exchange.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(content)),
+		ContentLength: int64(len(content)),
+		Request:       request,
+	}, nil
+}
+
+func (t *replayTransport) loadExchanges() error {
+	paths, err := filepath.Glob(filepath.Join(t.dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read recording: %w", err)
+		}
+		var exchange Exchange
+		if err := json.Unmarshal(content, &exchange); err != nil {
+			return fmt.Errorf("failed to read recording %s: %w", path, err)
+		}
+		t.exchanges = append(t.exchanges, exchange)
+	}
+	if len(t.exchanges) == 0 {
+		return fmt.Errorf("no recording to replay in %s", t.dir)
+	}
+	t.used = make([]bool, len(t.exchanges))
+	return nil
+}
+
+func (t *replayTransport) match(body []byte) (Exchange, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	requestBody := compactJSON(body)
+	key := replayKey(body)
+	found := -1
+	for i, exchange := range t.exchanges {
+		if !t.used[i] && compactJSON(exchange.Request.Body) == requestBody {
+			found = i
+			break
+		}
+	}
+	if found < 0 {
+		for i, exchange := range t.exchanges {
+			if !t.used[i] && replayKey(exchange.Request.Body) == key {
+				found = i
+				break
+			}
+		}
+	}
+	if found < 0 {
+		last, ok := t.last[key]
+		if !ok {
+			return Exchange{}, false
+		}
+		found = last
+	}
+
+	t.used[found] = true
+	t.last[key] = found
+	return t.exchanges[found], true
+}
+
+// timestampPattern matches the dates and times requests carry, e.g. 2026-10-18 or
+// 2026-10-18T09:30:00Z
+var timestampPattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}(T\d{2}:\d{2}(:\d{2}(\.\d+)?)?(Z|[+-]\d{2}:\d{2})?)?`)
+
+// replayKey identifies a request regardless of its dates and times: its operation with
+// every other variable, including the values Linear queries inline such as issue IDs
+func replayKey(body []byte) string {
+	return timestampPattern.ReplaceAllString(compactJSON(body), "<time>")
+}
+
+// graphQLRequest is what replay needs to know about a GraphQL request body
+type graphQLRequest struct {
+	Query         string `json:"query"`
+	OperationName string `json:"operationName"`
+}
+
+// operationName is the GraphQL operation name of a request body, or the name of its first
+// field such as "viewer", for file names and errors
+func operationName(body []byte) string {
+	var request graphQLRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return ""
+	}
+	if request.OperationName != "" {
+		return request.OperationName
+	}
+	query := strings.TrimSpace(request.Query)
+	if open := strings.Index(query, "{"); open >= 0 {
+		fields := strings.FieldsFunc(query[open+1:], func(r rune) bool {
+			return !(r == '_' || r >= '0' && r <= '9' || r >= 'A' && r <= 'Z' || r >= 'a' && r <= 'z')
+		})
+		if len(fields) > 0 {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// rawBody keeps a JSON body as it is, readable in the recording, and anything else as a string
+func rawBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	if json.Valid(body) {
+		return json.RawMessage(body)
+	}
+	quoted, _ := json.Marshal(string(body))
+	return quoted
+}
+
+// bodyBytes reverses rawBody
+func bodyBytes(raw json.RawMessage) []byte {
+	var text string
+	if len(raw) > 0 && raw[0] == '"' && json.Unmarshal(raw, &text) == nil {
+		return []byte(text)
+	}
+	return raw
+}
+
+func compactJSON(body []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, bodyBytes(body)); err != nil {
+		return string(body)
+	}
+	return compacted.String()
+}
@@ -0,0 +1,121 @@
+package daily
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test a recorded run is replayed without network or API keys
+func Test_RecordAndReplay_GetIssueDetails(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	mockServer := newMockServer(t, "../../mockResponses/issue-detail.json")
+	config := createTestConfig(mockServer.URL, "lin_api_secret")
+	config.Set("http.record", dir)
+
+	// Act
+	recorded, recordErr := GetIssueDetails(context.Background(), NewHTTPClient(config), "test-issue-id-001", config)
+	mockServer.Close()
+
+	replayConfig := viper.New()
+	replayConfig.Set("http.replay", dir)
+	configureErr := ConfigureReplay(replayConfig)
+	t.Cleanup(func() { os.RemoveAll(replayConfig.GetString("data.dir")) })
+	replayed, replayErr := GetIssueDetails(context.Background(), NewHTTPClient(replayConfig), "test-issue-id-001", replayConfig)
+	_, otherErr := GetIssueDetails(context.Background(), NewHTTPClient(replayConfig), "test-issue-id-002", replayConfig)
+
+	// Assert
+	require.NoError(t, recordErr)
+	require.NoError(t, configureErr)
+	require.NoError(t, replayErr)
+	assert.Equal(t, recorded, replayed)
+	assert.ErrorContains(t, otherErr, "no recorded response for GetIssueDetails", "another issue never gets this one's details")
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(dir, "0001-GetIssueDetails.json")}, files)
+	content, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "lin_api_secret")
+	assert.Contains(t, string(content), `"Authorization": "[REDACTED]"`)
+}
+
+// Test requests whose dates changed get the response recorded for the same request
+func Test_ReplayTransport_MatchesRequest(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	write := func(name string, body string, response string) {
+		content := `{"request": {"method": "POST", "url": "https://api.github.com/graphql", "body": ` + body + `},
+			"response": {"status": 200, "headers": {"Content-Type": "application/json"}, "body": ` + response + `}}`
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+	write("0001-viewer.json", `{"query": "query($from: DateTime!) { viewer { login } }", "variables": {"from": "2026-10-01"}}`, `{"data": {"viewer": {"login": "first"}}}`)
+	write("0002-viewer.json", `{"query": "query($from: DateTime!) { viewer { login } }", "variables": {"from": "2026-10-02"}}`, `{"data": {"viewer": {"login": "second"}}}`)
+	client := &http.Client{Transport: NewReplayTransport(dir)}
+	send := func(body string) (string, error) {
+		response, err := client.Post("https://api.github.com/graphql", "application/json", strings.NewReader(body))
+		if err != nil {
+			return "", err
+		}
+		defer response.Body.Close()
+		content, err := io.ReadAll(response.Body)
+		return string(content), err
+	}
+
+	// Act
+	exact, exactErr := send(`{"query":"query($from: DateTime!) { viewer { login } }","variables":{"from":"2026-10-02"}}`)
+	changed, changedErr := send(`{"query":"query($from: DateTime!) { viewer { login } }","variables":{"from":"2026-10-18"}}`)
+	again, againErr := send(`{"query":"query($from: DateTime!) { viewer { login } }","variables":{"from":"2026-10-19"}}`)
+	_, unknownErr := send(`{"query":"{ organization { name } }"}`)
+	_, otherErr := send(`{"query":"query($from: DateTime!) { viewer { login } }","variables":{"from":"2026-10-18","repo":"acme/api"}}`)
+
+	// Assert
+	require.NoError(t, exactErr)
+	require.NoError(t, changedErr)
+	require.NoError(t, againErr)
+	assert.JSONEq(t, `{"data": {"viewer": {"login": "second"}}}`, exact)
+	assert.JSONEq(t, `{"data": {"viewer": {"login": "first"}}}`, changed)
+	assert.JSONEq(t, `{"data": {"viewer": {"login": "first"}}}`, again)
+	assert.ErrorContains(t, unknownErr, "no recorded response for organization")
+	assert.ErrorContains(t, otherErr, "no recorded response", "other variables must match")
+}
+
+// Test replaying fills in API keys, and cannot be combined with recording
+func Test_ConfigureReplay(t *testing.T) {
+	// Arrange
+	config := viper.New()
+	config.Set("http.replay", t.TempDir())
+	both := viper.New()
+	both.Set("http.replay", t.TempDir())
+	both.Set("http.record", t.TempDir())
+	missing := viper.New()
+	missing.Set("http.replay", filepath.Join(t.TempDir(), "missing"))
+
+	config.Set("data.dir", "~/.local/share/mastercrab")
+
+	// Act
+	err := ConfigureReplay(config)
+	t.Cleanup(func() { os.RemoveAll(config.GetString("data.dir")) })
+	bothErr := ConfigureReplay(both)
+	missingErr := ConfigureReplay(missing)
+	enabled, _ := EnabledSources(config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"github", "linear"}, sourceNames(enabled))
+	assert.DirExists(t, config.GetString("data.dir"), "the replay gets its own data directory")
+	assert.Contains(t, config.GetString("data.dir"), "mastercrab-replay-")
+	assert.Equal(t, config.GetString("data.dir"), config.GetString("output.dir"))
+	assert.ErrorContains(t, bothErr, "cannot be used together")
+	assert.ErrorContains(t, missingErr, "no recording to replay")
+	assert.NoError(t, ConfigureReplay(viper.New()))
+}
@@ -0,0 +1,193 @@
+package daily
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+//go:embed templates/report.md.tmpl
+var defaultReportTemplate string
+
+// RollupDays combines the stored days of a report into one export. Notes are merged per
+// Linear issue, commits are summed per repository, and pull requests, reviews and issues
+// are listed once with their latest state. days must be in order, oldest first.
+func RollupDays(days []DailyExport) DailyExport {
+	rollup := DailyExport{
+		SchemaVersion: ExportSchemaVersion,
+		GitHub: ExportGitHub{
+			Commits:      []ExportRepoCommits{},
+			PullRequests: []ExportPullRequest{},
+			Reviews:      []ExportPullRequest{},
+			Issues:       []ExportGitHubIssue{},
+		},
+		Issues:  []ExportIssue{},
+		Sources: []ExportSource{},
+	}
+
+	commits := make(map[string]int)
+	totalCommits := 0
+	for i, day := range days {
+		if i == 0 || day.Window.Since.Before(rollup.Window.Since) {
+			rollup.Window.Since = day.Window.Since
+		}
+		if day.Window.Until.After(rollup.Window.Until) {
+			rollup.Window.Until = day.Window.Until
+		}
+		if day.GeneratedAt.After(rollup.GeneratedAt) {
+			rollup.GeneratedAt = day.GeneratedAt
+		}
+		if day.GitHub.Username != "" {
+			rollup.GitHub.Username = day.GitHub.Username
+		}
+
+		// Each day counts its own commits, so a week is the sum of its days
+		for _, repo := range day.GitHub.Commits {
+			commits[repo.Repo] += repo.Commits
+		}
+		totalCommits += day.GitHub.Totals.Commits
+
+		// The other parts of two days combine the same way as two runs of one day
+		merged := MergeExports(rollup, day)
+		merged.GitHub.Totals = ExportGitHubTotals{
+			Commits:      totalCommits,
+			PullRequests: len(merged.GitHub.PullRequests),
+			Reviews:      len(merged.GitHub.Reviews),
+			Issues:       len(merged.GitHub.Issues),
+		}
+		merged.Window, merged.GeneratedAt = rollup.Window, rollup.GeneratedAt
+		merged.Unavailable = mergeByKey(rollup.Unavailable, day.Unavailable, func(status ExportUnavailable) string {
+			return status.Source + " " + status.Reason
+		})
+		merged.Sources = rollup.Sources
+		merged.Plan, merged.Blockers = nil, nil
+		// Each day is estimated on its own, so a week is the sum of its days too
+		merged.ActiveTime = mergeActiveTime(rollup.ActiveTime, day.ActiveTime, func(a int, b int) int { return a + b })
+		rollup = merged
+	}
+
+	rollup.GitHub.Commits = []ExportRepoCommits{}
+	for _, repo := range sortedKeys(commits) {
+		rollup.GitHub.Commits = append(rollup.GitHub.Commits, ExportRepoCommits{Repo: repo, Commits: commits[repo]})
+	}
+	if len(rollup.Unavailable) == 0 {
+		rollup.Unavailable = nil
+	}
+	return rollup
+}
+
+// MergedPullRequests returns the pull requests created that were merged
+func (d SummaryData) MergedPullRequests() []GitHubPullRequest {
+	return d.pullRequestsCreated(func(state string) bool { return strings.EqualFold(state, "merged") })
+}
+
+// OtherPullRequests returns the pull requests created that were not merged (yet)
+func (d SummaryData) OtherPullRequests() []GitHubPullRequest {
+	return d.pullRequestsCreated(func(state string) bool { return !strings.EqualFold(state, "merged") })
+}
+
+func (d SummaryData) pullRequestsCreated(match func(state string) bool) []GitHubPullRequest {
+	var pullRequests []GitHubPullRequest
+	for _, pr := range d.GitHub.PullRequestsCreated {
+		if match(pr.State) {
+			pullRequests = append(pullRequests, pr)
+		}
+	}
+	return pullRequests
+}
+
+// ReportWindow is the window of a report from the start of one day to the end of another,
+// never reaching past now
+func ReportWindow(from time.Time, to time.Time, now time.Time) Window {
+	since := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	until := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, to.Location()).AddDate(0, 0, 1).Add(-time.Second)
+	if until.After(now) {
+		until = now
+	}
+	return Window{Since: since, Until: until}
+}
+
+// WeekStart returns the Monday of the week date is in
+func WeekStart(date time.Time) time.Time {
+	offset := (int(date.Weekday()) + 6) % 7
+	day := date.AddDate(0, 0, -offset)
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// FetchRange fetches every enabled source for the window without asking anything. Every
+// Linear issue is included without notes. Sources and issues that could not be fetched
+// are listed as unavailable.
+func FetchRange(ctx context.Context, client *http.Client, window Window, config *viper.Viper) SummaryData {
+	sources, unconfigured := EnabledSources(config)
+	fetches := FetchAll(ctx, client, sources, window, config, nil)
+
+	data := SummaryData{
+		Date:        window.Until,
+		Window:      window,
+		Unavailable: UnavailableSources(fetches),
+		Sources:     SourceReports(fetches, unconfigured),
+	}
+
+	var issues []ActivityItem
+	for _, fetch := range fetches {
+		if fetch.Err != nil {
+			continue
+		}
+		issues = append(issues, ItemsOfKind(fetch.Result.Items, ItemKindLinearIssue)...)
+		if activity, ok := fetch.Result.Data.(GitHubActivity); ok {
+			data.GitHub = activity
+		}
+	}
+
+	fetchDetails := func(ctx context.Context, issueID string) (LinearIssueDetails, error) {
+		ctx, cancel := context.WithTimeout(ctx, SourceTimeout("linear", config))
+		defer cancel()
+		return GetIssueDetails(ctx, client, issueID, config)
+	}
+	for fetched := range PrefetchIssueDetails(ctx, issues, DefaultPrefetch, fetchDetails) {
+		if fetched.Err != nil {
+			data.Unavailable = append(data.Unavailable, SourceStatus{
+				Source: fmt.Sprintf("linear (%s)", fetched.Item.Title),
+				Reason: fetched.Err.Error(),
+			})
+			continue
+		}
+		data.Issues = append(data.Issues, IssueWithNotes{Details: fetched.Details})
+	}
+	return data
+}
+
+// ReportData builds the data of a report from the stored days and, when fresh is given,
+// the data fetched for the whole range. Fresh data brings the latest state and counts,
+// the stored days bring the notes. Without it, pull requests keep the state of the last
+// daily run that saw them. Issues answered as not worked on stay that way.
+func ReportData(days []DailyExport, fresh *SummaryData, window Window) SummaryData {
+	rollup := RollupDays(days)
+	if fresh != nil {
+		notWorked := make(map[string]bool)
+		for _, issue := range rollup.NotWorked {
+			notWorked[issue.Identifier] = true
+		}
+		current := *fresh
+		current.Issues = nil
+		for _, issue := range fresh.Issues {
+			if !notWorked[issue.Details.Identifier] {
+				current.Issues = append(current.Issues, issue)
+			}
+		}
+
+		rollup = MergeExports(rollup, NewDailyExport(current))
+	}
+
+	data := rollup.SummaryData()
+	data.Fetched = fresh != nil
+	data.Window = window
+	data.Date = window.Until
+	data.Days = window.Days()
+	return data
+}
@@ -0,0 +1,104 @@
+package daily
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportDay(date time.Time, commits int, notes string, prState string) DailyExport {
+	data := sampleSummaryData()
+	data.Date = date
+	data.Window = Window{Since: date.Add(-24 * time.Hour), Until: date}
+	data.GitHub.TotalCommits = commits
+	data.GitHub.CommitsByRepo = map[string]int{"testorg/mastercrab": commits}
+	data.GitHub.PullRequestsCreated[0].State = prState
+	data.Issues[0].UserNotes = notes
+	return NewDailyExport(data)
+}
+
+// Test days are rolled up with notes merged per issue, commits summed and PRs listed once
+func Test_RollupDays(t *testing.T) {
+	// Arrange
+	monday := time.Date(2025, 10, 20, 18, 0, 0, 0, time.UTC)
+	days := []DailyExport{
+		reportDay(monday, 3, "Started the auth flow", "OPEN"),
+		reportDay(monday.AddDate(0, 0, 1), 2, "Started the auth flow\nAdded token refresh", "MERGED"),
+	}
+	days[1].Entries = []ExportEntry{{Text: "Sprint planning", At: monday.AddDate(0, 0, 1)}}
+
+	// Act
+	rollup := RollupDays(days)
+
+	// Assert
+	assert.Equal(t, []ExportRepoCommits{{Repo: "testorg/mastercrab", Commits: 5}}, rollup.GitHub.Commits)
+	assert.Equal(t, 5, rollup.GitHub.Totals.Commits)
+	require.Len(t, rollup.Issues, 1)
+	assert.Equal(t, "Started the auth flow\nAdded token refresh", rollup.Issues[0].Notes)
+	require.Len(t, rollup.GitHub.PullRequests, 1)
+	assert.Equal(t, "MERGED", rollup.GitHub.PullRequests[0].State)
+	assert.Equal(t, 1, rollup.GitHub.Totals.PullRequests)
+	assert.Len(t, rollup.Entries, 1)
+	assert.Equal(t, monday.Add(-24*time.Hour), rollup.Window.Since)
+	assert.Equal(t, monday.AddDate(0, 0, 1), rollup.Window.Until)
+}
+
+// Test fresh data brings the latest state while notes and "not worked" answers are kept
+func Test_ReportData_Fresh(t *testing.T) {
+	// Arrange
+	monday := time.Date(2025, 10, 20, 18, 0, 0, 0, time.UTC)
+	day := reportDay(monday, 3, "Started the auth flow", "OPEN")
+	day.NotWorked = []ExportIssue{{Identifier: "TEST-9", Title: "Someday"}}
+
+	fresh := sampleSummaryData()
+	fresh.GitHub.PullRequestsCreated[0].State = "MERGED"
+	fresh.Issues = append(fresh.Issues, IssueWithNotes{Details: LinearIssueDetails{Identifier: "TEST-9", Title: "Someday"}})
+	fresh.Issues[0].UserNotes = ""
+	window := ReportWindow(monday, monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 4))
+
+	// Act
+	data := ReportData([]DailyExport{day}, &fresh, window)
+
+	// Assert
+	require.Len(t, data.Issues, 1)
+	assert.Equal(t, "Started the auth flow", data.Issues[0].UserNotes)
+	assert.Len(t, data.MergedPullRequests(), 1)
+	assert.Empty(t, data.OtherPullRequests())
+	assert.Equal(t, 5, data.Days)
+	assert.True(t, data.Fetched)
+	var out bytes.Buffer
+	require.NoError(t, RenderSummary(&out, data, ""))
+	assert.NotContains(t, out.String(), "use --fetch for the latest")
+}
+
+// Test reports use the report template, listing merged pull requests on their own
+func Test_RenderSummary_Report(t *testing.T) {
+	// Arrange
+	monday := time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC)
+	window := ReportWindow(monday, monday.AddDate(0, 0, 4), monday.AddDate(0, 0, 7))
+	data := ReportData([]DailyExport{reportDay(monday.Add(18*time.Hour), 3, "Added token refresh", "MERGED")}, nil, window)
+
+	// Act
+	var out bytes.Buffer
+	err := RenderSummary(&out, data, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "# Work Summary - Monday, October 20 to Friday, October 24, 2025\n")
+	assert.Contains(t, out.String(), "## Merged Pull Requests\n\n_State at the time of the daily runs, use --fetch for the latest._\n\n- [testorg/mastercrab#10: feat: Add daily command](https://github.com/testorg/mastercrab/pull/10)\n")
+	assert.Contains(t, out.String(), "## Commits\n\n- testorg/mastercrab: 3 commit(s)\n")
+	assert.NotContains(t, out.String(), "## Open Pull Requests")
+}
+
+// Test weeks start on Monday and windows count the days they touch
+func Test_WeekStart(t *testing.T) {
+	friday := time.Date(2025, 10, 24, 16, 0, 0, 0, time.UTC)
+	sunday := time.Date(2025, 10, 26, 16, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC), WeekStart(friday))
+	assert.Equal(t, time.Date(2025, 10, 20, 0, 0, 0, 0, time.UTC), WeekStart(sunday))
+	assert.Equal(t, 5, Window{Since: WeekStart(friday), Until: friday}.Days())
+}
@@ -0,0 +1,255 @@
+package daily
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Sources a search can be narrowed down to
+const (
+	SearchSourceNotes  = "notes"
+	SearchSourceLinear = "linear"
+	SearchSourceGitHub = "github"
+)
+
+// SearchSources lists the sources a search can be narrowed down to
+var SearchSources = []string{SearchSourceNotes, SearchSourceLinear, SearchSourceGitHub}
+
+// SearchHit is a piece of a recorded day matching a search, the latest day it was seen on
+type SearchHit struct {
+	Date time.Time
+	// Source is notes, linear or github
+	Source string
+	// Kind is what was matched, e.g. "issue", "pull request" or "commit"
+	Kind    string
+	Title   string
+	URL     string
+	Snippet string
+	Score   float64
+	// Days is the number of recorded days the same text was seen on
+	Days int
+}
+
+// SearchFilter narrows down a search. Zero values match everything.
+type SearchFilter struct {
+	Since   time.Time
+	Until   time.Time
+	Sources []string
+}
+
+// searchDocument is one indexed piece of text
+type searchDocument struct {
+	hit   SearchHit
+	text  string
+	terms map[string]int
+	count int
+}
+
+// SearchIndex is an in-memory full-text index of recorded days
+type SearchIndex struct {
+	documents []*searchDocument
+	// frequency is the number of documents each term appears in
+	frequency map[string]int
+}
+
+// NewSearchIndex indexes the notes, Linear issue titles and descriptions, pull request and
+// issue titles and commit headlines of the days. Text seen on several days is indexed once,
+// dated with the latest day.
+func NewSearchIndex(days []DailyExport) *SearchIndex {
+	index := &SearchIndex{frequency: make(map[string]int)}
+	seen := make(map[string]*searchDocument)
+
+	add := func(date time.Time, source string, kind string, title string, url string, text string) {
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return
+		}
+		key := strings.Join([]string{source, kind, url, text}, "\x00")
+		if document, ok := seen[key]; ok {
+			document.hit.Days++
+			if date.After(document.hit.Date) {
+				document.hit.Date = date
+			}
+			return
+		}
+
+		document := &searchDocument{
+			hit:   SearchHit{Date: date, Source: source, Kind: kind, Title: title, URL: url, Days: 1},
+			text:  text,
+			terms: make(map[string]int),
+		}
+		for _, term := range searchTerms(text) {
+			if document.terms[term] == 0 {
+				index.frequency[term]++
+			}
+			document.terms[term]++
+			document.count++
+		}
+		seen[key] = document
+		index.documents = append(index.documents, document)
+	}
+
+	for _, day := range days {
+		date := day.GeneratedAt
+		for _, issue := range append(append([]ExportIssue{}, day.Issues...), day.NotWorked...) {
+			title := issue.Identifier + " " + issue.Title
+			add(date, SearchSourceLinear, "issue", title, issue.URL, issue.Title)
+			add(date, SearchSourceLinear, "description", title, issue.URL, issue.Description)
+			add(date, SearchSourceNotes, "issue notes", title, issue.URL, issue.Notes)
+		}
+		for _, pr := range day.GitHub.PullRequests {
+			title := fmt.Sprintf("%s#%d %s", pr.Repo, pr.Number, pr.Title)
+			add(date, SearchSourceGitHub, "pull request", title, pr.URL, pr.Title)
+			add(date, SearchSourceNotes, "pull request notes", title, pr.URL, pr.Notes)
+		}
+		for _, pr := range day.GitHub.Reviews {
+			title := fmt.Sprintf("%s#%d %s", pr.Repo, pr.Number, pr.Title)
+			add(date, SearchSourceGitHub, "review", title, pr.URL, pr.Title)
+			add(date, SearchSourceNotes, "review notes", title, pr.URL, pr.Notes)
+		}
+		for _, issue := range day.GitHub.Issues {
+			title := fmt.Sprintf("%s#%d %s", issue.Repo, issue.Number, issue.Title)
+			add(date, SearchSourceGitHub, "issue", title, issue.URL, issue.Title)
+			add(date, SearchSourceNotes, "issue notes", title, issue.URL, issue.Notes)
+		}
+		for _, commit := range day.GitHub.CommitHeadlines {
+			add(date, SearchSourceGitHub, "commit", commit.Repo+" "+commit.Headline, commit.URL, commit.Headline)
+		}
+		for _, entry := range day.Entries {
+			add(date, SearchSourceNotes, "entry", entry.Category, "", entry.Text)
+		}
+		for _, blocker := range day.Blockers {
+			add(date, SearchSourceNotes, "blocker", strings.TrimSpace(blocker.Identifier+" "+blocker.Title), blocker.URL, blocker.Reason)
+		}
+	}
+	return index
+}
+
+// Search returns the documents containing every term of the query, best matches first.
+// Matches are ranked by TF-IDF, with a bonus when the query appears as a phrase, and by
+// date when they score the same.
+func (index *SearchIndex) Search(query string, filter SearchFilter) []SearchHit {
+	terms := searchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	phrase := strings.ToLower(strings.TrimSpace(query))
+
+	var hits []SearchHit
+	for _, document := range index.documents {
+		if !filter.matches(document.hit) {
+			continue
+		}
+
+		score := 0.0
+		for _, term := range terms {
+			count := document.terms[term]
+			if count == 0 {
+				score = 0
+				break
+			}
+			idf := math.Log(1 + float64(len(index.documents))/float64(index.frequency[term]))
+			score += float64(count) / float64(document.count) * idf
+		}
+		if score == 0 {
+			continue
+		}
+		if strings.Contains(strings.ToLower(document.text), phrase) {
+			score *= 2
+		}
+
+		hit := document.hit
+		hit.Score = score
+		hit.Snippet = searchSnippet(document.text, terms)
+		hits = append(hits, hit)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Date.After(hits[j].Date)
+	})
+	return hits
+}
+
+func (f SearchFilter) matches(hit SearchHit) bool {
+	if !f.Since.IsZero() && DayKey(hit.Date) < DayKey(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && DayKey(hit.Date) > DayKey(f.Until) {
+		return false
+	}
+	return len(f.Sources) == 0 || containsFold(f.Sources, hit.Source)
+}
+
+// ParseSearchSources validates the sources a search is narrowed down to
+func ParseSearchSources(sources []string) ([]string, error) {
+	for _, source := range sources {
+		if !containsFold(SearchSources, source) {
+			return nil, fmt.Errorf("unknown source %q (expected %s)", source, strings.Join(SearchSources, ", "))
+		}
+	}
+	return sources, nil
+}
+
+// searchTerms splits text into lowercase words, reduced to a common stem so that
+// "retries", "retrying" and "retry" match each other
+func searchTerms(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, searchStem(word))
+	}
+	return terms
+}
+
+func searchStem(word string) string {
+	for _, suffix := range []struct{ from, to string }{
+		{"ies", "y"}, {"ied", "y"}, {"ing", ""}, {"ed", ""}, {"s", ""},
+	} {
+		if len(word) > len(suffix.from)+2 && strings.HasSuffix(word, suffix.from) && !strings.HasSuffix(word, "ss") {
+			return strings.TrimSuffix(word, suffix.from) + suffix.to
+		}
+	}
+	return word
+}
+
+// searchSnippetLength is the most characters of a matching text shown with a hit
+const searchSnippetLength = 120
+
+// searchSnippet is the first line of text with a matching term, shortened when too long
+func searchSnippet(text string, terms []string) string {
+	lines := strings.Split(text, "\n")
+	line := strings.TrimSpace(lines[0])
+	for _, candidate := range lines {
+		if containsTerm(candidate, terms) {
+			line = strings.TrimSpace(candidate)
+			break
+		}
+	}
+
+	runes := []rune(line)
+	if len(runes) <= searchSnippetLength {
+		return line
+	}
+	return string(runes[:searchSnippetLength-1]) + "…"
+}
+
+func containsTerm(text string, terms []string) bool {
+	for _, term := range searchTerms(text) {
+		for _, wanted := range terms {
+			if term == wanted {
+				return true
+			}
+		}
+	}
+	return false
+}
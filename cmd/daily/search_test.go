@@ -0,0 +1,137 @@
+package daily
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchDays() []DailyExport {
+	may := time.Date(2026, 5, 4, 18, 0, 0, 0, time.UTC)
+	june := time.Date(2026, 6, 8, 18, 0, 0, 0, time.UTC)
+	return []DailyExport{
+		{
+			GeneratedAt: may,
+			Issues: []ExportIssue{{
+				Identifier:  "PAY-12",
+				Title:       "Payment webhook retries",
+				URL:         "https://linear.app/acme/issue/PAY-12",
+				Description: "Retry failed webhooks with a backoff",
+				Notes:       "Retried the stuck payment webhooks by hand",
+			}},
+			GitHub: ExportGitHub{
+				CommitHeadlines: []ExportCommit{{Repo: "acme/api", Headline: "Add exponential backoff to webhook retrying", URL: "https://github.com/acme/api/commit/abc"}},
+			},
+		},
+		{
+			GeneratedAt: june,
+			Issues: []ExportIssue{{
+				Identifier: "PAY-12",
+				Title:      "Payment webhook retries",
+				URL:        "https://linear.app/acme/issue/PAY-12",
+			}},
+			GitHub: ExportGitHub{
+				PullRequests: []ExportPullRequest{{Repo: "acme/web", Number: 7, Title: "Dashboard filters", URL: "https://github.com/acme/web/pull/7", Notes: "Webhook list can be filtered"}},
+			},
+			Entries: []ExportEntry{{Text: "Paired on the invoice export", At: june}},
+		},
+	}
+}
+
+// Test every word has to match, with plural and -ing forms matching each other
+func Test_SearchIndex_Search(t *testing.T) {
+	// Arrange
+	index := NewSearchIndex(searchDays())
+
+	// Act
+	hits := index.Search("webhook retry", SearchFilter{})
+
+	// Assert
+	require.Len(t, hits, 4)
+	assert.Equal(t, "issue", hits[0].Kind)
+	assert.Equal(t, "PAY-12 Payment webhook retries", hits[0].Title)
+	assert.Equal(t, time.Date(2026, 6, 8, 18, 0, 0, 0, time.UTC), hits[0].Date)
+	assert.Equal(t, 2, hits[0].Days)
+	var kinds []string
+	for _, hit := range hits {
+		kinds = append(kinds, hit.Kind)
+	}
+	assert.ElementsMatch(t, []string{"issue", "description", "issue notes", "commit"}, kinds)
+}
+
+// Test hits are narrowed down by source and date
+func Test_SearchIndex_Filter(t *testing.T) {
+	// Arrange
+	index := NewSearchIndex(searchDays())
+
+	// Act
+	notes := index.Search("webhook", SearchFilter{Sources: []string{SearchSourceNotes}})
+	june := index.Search("webhook", SearchFilter{Since: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)})
+
+	// Assert
+	require.Len(t, notes, 2)
+	for _, hit := range notes {
+		assert.Equal(t, SearchSourceNotes, hit.Source)
+	}
+	require.Len(t, june, 2)
+	for _, hit := range june {
+		assert.Equal(t, 2026, hit.Date.Year())
+		assert.Equal(t, time.June, hit.Date.Month())
+	}
+	assert.Empty(t, index.Search("", SearchFilter{}))
+	assert.Empty(t, index.Search("webhook kubernetes", SearchFilter{}))
+}
+
+// Test unknown sources are rejected
+func Test_ParseSearchSources(t *testing.T) {
+	// Act
+	sources, err := ParseSearchSources([]string{"notes", "GitHub"})
+	_, unknownErr := ParseSearchSources([]string{"jira"})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"notes", "GitHub"}, sources)
+	require.Error(t, unknownErr)
+	assert.Contains(t, unknownErr.Error(), "jira")
+}
+
+// Test commit headlines are fetched for the viewer's node ID
+func Test_GetViewerCommits(t *testing.T) {
+	// Arrange
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request GitHubRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Variables == nil {
+			w.Write([]byte(`{"data":{"viewer":{"id":"U_1"}}}`))
+			return
+		}
+		assert.Equal(t, "U_1", request.Variables["author"])
+		w.Write([]byte(`{"data":{"viewer":{"contributionsCollection":{"commitContributionsByRepository":[
+			{"repository":{"name":"api","owner":{"login":"acme"},"defaultBranchRef":{"target":{"history":{"nodes":[
+				{"messageHeadline":"Add backoff","url":"https://github.com/acme/api/commit/abc","committedDate":"2026-05-04T10:00:00Z"}
+			]}}}}}
+		]}}}}`))
+	}))
+	defer mockServer.Close()
+
+	previous := githubGraphQLURL
+	githubGraphQLURL = mockServer.URL
+	defer func() { githubGraphQLURL = previous }()
+
+	config := viper.New()
+	config.Set("github.apiToken", "test-token")
+
+	// Act
+	commits, err := GetViewerCommits(context.Background(), mockServer.Client(), time.Now().Add(-24*time.Hour), time.Now(), config)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []GitHubCommit{{Repo: "acme/api", Headline: "Add backoff", URL: "https://github.com/acme/api/commit/abc", CommittedAt: "2026-05-04T10:00:00Z"}}, commits)
+}
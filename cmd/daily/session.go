@@ -0,0 +1,208 @@
+package daily
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Session is an in-progress daily run. Every answer is written to disk as soon as it is
+// given so a crash or a closed terminal does not lose typed notes.
+type Session struct {
+	StartedAt time.Time       `json:"startedAt"`
+	Window    ExportWindow    `json:"window"`
+	Answers   []SessionAnswer `json:"answers"`
+	Items     []SessionItem   `json:"items,omitempty"`
+	Entries   []ExportEntry   `json:"entries,omitempty"`
+
+	path string
+}
+
+// SessionAnswer is the answer given for one Linear issue
+type SessionAnswer struct {
+	Issue   ExportIssue `json:"issue"`
+	Worked  bool        `json:"worked"`
+	Skipped bool        `json:"skipped,omitempty"`
+}
+
+// SessionItem is the answer given for a GitHub item
+type SessionItem struct {
+	URL      string `json:"url"`
+	Excluded bool   `json:"excluded,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+}
+
+func sessionPath(dataDir string) string {
+	return filepath.Join(dataDir, "session.json")
+}
+
+// NewSession starts a session for a run. Nothing is written until the first answer.
+func NewSession(dataDir string, startedAt time.Time, window Window) *Session {
+	return &Session{
+		StartedAt: startedAt,
+		Window:    ExportWindow{Since: window.Since, Until: window.Until},
+		path:      sessionPath(dataDir),
+	}
+}
+
+// LoadSession reads an unfinished session left by an earlier run, or returns nil
+func LoadSession(dataDir string) (*Session, error) {
+	path := sessionPath(dataDir)
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read unfinished session: %w", err)
+	}
+
+	var session Session
+	if err := json.Unmarshal(content, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse unfinished session %s: %w", path, err)
+	}
+	session.path = path
+	return &session, nil
+}
+
+// Record adds an answer, or replaces an earlier answer for the same issue, and saves
+// the session right away
+func (s *Session) Record(issue LinearIssueDetails, notes string, worked bool, skipped bool) error {
+	answer := SessionAnswer{
+		Issue:   exportIssue(issue, notes),
+		Worked:  worked,
+		Skipped: skipped,
+	}
+
+	for i, existing := range s.Answers {
+		if existing.Issue.ID == issue.ID {
+			s.Answers[i] = answer
+			return s.Save()
+		}
+	}
+	s.Answers = append(s.Answers, answer)
+	return s.Save()
+}
+
+// RecordTimeSpent sets the time spent on an issue answered earlier and saves the session
+func (s *Session) RecordTimeSpent(issue LinearIssueDetails, spent time.Duration) error {
+	for i, existing := range s.Answers {
+		if existing.Issue.ID == issue.ID {
+			s.Answers[i].Issue.Minutes = int(spent / time.Minute)
+			return s.Save()
+		}
+	}
+	return fmt.Errorf("%s has not been answered", issue.Identifier)
+}
+
+// RecordItem adds an answer for a GitHub item, or replaces an earlier one, and saves the session
+func (s *Session) RecordItem(item ActivityItem, notes string, excluded bool) error {
+	answer := SessionItem{URL: item.URL, Excluded: excluded, Notes: notes}
+	for i, existing := range s.Items {
+		if existing.URL == item.URL {
+			s.Items[i] = answer
+			return s.Save()
+		}
+	}
+	s.Items = append(s.Items, answer)
+	return s.Save()
+}
+
+// ItemAnswers maps the URLs of the GitHub items answered in the session to their answers
+func (s *Session) ItemAnswers() map[string]ItemAnswer {
+	answers := make(map[string]ItemAnswer)
+	for _, item := range s.Items {
+		answers[item.URL] = ItemAnswer{Excluded: item.Excluded, Notes: item.Notes}
+	}
+	return answers
+}
+
+// RecordEntry adds a free-form entry and saves the session right away
+func (s *Session) RecordEntry(entry FreeFormEntry) error {
+	s.Entries = append(s.Entries, exportEntry(entry))
+	return s.Save()
+}
+
+// FreeFormEntries returns the free-form entries given in the session
+func (s *Session) FreeFormEntries() []FreeFormEntry {
+	var entries []FreeFormEntry
+	for _, entry := range s.Entries {
+		entries = append(entries, entry.entry())
+	}
+	return entries
+}
+
+// Save writes the session atomically, so an interrupted write never corrupts it
+func (s *Session) Save() error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	temp := s.path + ".tmp"
+	if err := os.WriteFile(temp, content, 0o600); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	if err := os.Rename(temp, s.path); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Discard removes the session once its answers are safely part of a summary
+func (s *Session) Discard() error {
+	if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove session: %w", err)
+	}
+	return nil
+}
+
+// Answered maps the Linear issue IDs answered in the session to their notes
+func (s *Session) Answered() map[string]string {
+	answered := make(map[string]string)
+	for _, answer := range s.Answers {
+		answered[answer.Issue.ID] = answer.Issue.Notes
+	}
+	return answered
+}
+
+// Results splits the session's answers into worked and not-worked issues
+func (s *Session) Results() ([]IssueWithNotes, []LinearIssueDetails) {
+	var worked []IssueWithNotes
+	var notWorked []LinearIssueDetails
+	for _, answer := range s.Answers {
+		switch {
+		case answer.Skipped:
+		case answer.Worked:
+			worked = append(worked, IssueWithNotes{Details: answer.Issue.details(), UserNotes: answer.Issue.Notes, TimeSpent: answer.Issue.timeSpent()})
+		default:
+			notWorked = append(notWorked, answer.Issue.details())
+		}
+	}
+	return worked, notWorked
+}
+
+// PromptResumeSession asks whether to pick up an unfinished session. Enter or "y" resumes,
+// "n" or the end of the input starts over.
+func PromptResumeSession(input io.Reader, session *Session) bool {
+	fmt.Fprintf(console, "\n💾 Found an unfinished session from %s with %d answer(s).\n",
+		session.StartedAt.Format("Monday 15:04"), len(session.Answers))
+	fmt.Fprintln(console, "❓ Resume where you stopped? (Y/n)")
+	fmt.Fprint(console, "▶ ")
+
+	response, err := bufio.NewReader(input).ReadString('\n')
+	if err != nil && response == "" {
+		return false
+	}
+
+	response = strings.TrimSpace(strings.ToLower(response))
+	return response != "n" && response != "no"
+}
@@ -0,0 +1,80 @@
+package daily
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test every answer is on disk as soon as it is recorded and can be resumed
+func Test_Session_RecordAndResume(t *testing.T) {
+	// Arrange
+	dataDir := t.TempDir()
+	startedAt := time.Date(2025, 10, 22, 17, 30, 0, 0, time.UTC)
+	window := Window{Since: startedAt.Add(-24 * time.Hour), Until: startedAt}
+	session := NewSession(dataDir, startedAt, window)
+
+	// Act
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-1", Identifier: "TEST-1"}, "Wrote the migration", true, false))
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-2", Identifier: "TEST-2"}, "", false, false))
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-3", Identifier: "TEST-3"}, "", false, true))
+	resumed, err := LoadSession(dataDir)
+
+	// Assert
+	require.NoError(t, err)
+	require.NotNil(t, resumed)
+	assert.Equal(t, startedAt, resumed.StartedAt.UTC())
+	assert.Equal(t, window.Since, resumed.Window.Since.UTC())
+
+	worked, notWorked := resumed.Results()
+	require.Len(t, worked, 1)
+	assert.Equal(t, "TEST-1", worked[0].Details.Identifier)
+	assert.Equal(t, "Wrote the migration", worked[0].UserNotes)
+	require.Len(t, notWorked, 1)
+	assert.Equal(t, "TEST-2", notWorked[0].Identifier)
+	assert.Len(t, resumed.Answered(), 3, "skipped issues are not asked again either")
+}
+
+// Test answering an issue again replaces the earlier answer
+func Test_Session_RecordReplacesAnswer(t *testing.T) {
+	session := NewSession(t.TempDir(), time.Now(), Window{})
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-1"}, "", false, false))
+
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-1"}, "Changed my mind", true, false))
+
+	require.Len(t, session.Answers, 1)
+	worked, notWorked := session.Results()
+	require.Len(t, worked, 1)
+	assert.Equal(t, "Changed my mind", worked[0].UserNotes)
+	assert.Empty(t, notWorked)
+}
+
+// Test a discarded session is gone and loading finds nothing
+func Test_Session_Discard(t *testing.T) {
+	dataDir := t.TempDir()
+	session := NewSession(dataDir, time.Now(), Window{})
+	require.NoError(t, session.Record(LinearIssueDetails{ID: "issue-1"}, "notes", true, false))
+	assert.FileExists(t, filepath.Join(dataDir, "session.json"))
+
+	require.NoError(t, session.Discard())
+
+	loaded, err := LoadSession(dataDir)
+	require.NoError(t, err)
+	assert.Nil(t, loaded)
+	_, err = os.Stat(filepath.Join(dataDir, "session.json.tmp"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+// Test the resume prompt defaults to resuming, but not without an answer
+func Test_PromptResumeSession(t *testing.T) {
+	session := &Session{StartedAt: time.Now()}
+
+	assert.True(t, PromptResumeSession(strings.NewReader("\n"), session))
+	assert.False(t, PromptResumeSession(strings.NewReader(""), session))
+	assert.False(t, PromptResumeSession(strings.NewReader("n\n"), session))
+}
@@ -0,0 +1,137 @@
+package daily
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Item kinds produced by the built-in activity sources
+const (
+	ItemKindCommits     = "commits"
+	ItemKindPullRequest = "pull_request"
+	ItemKindReview      = "review"
+	ItemKindIssue       = "issue"
+	ItemKindLinearIssue = "linear_issue"
+)
+
+// Window is the time period a daily run covers
+type Window struct {
+	Since time.Time
+	Until time.Time
+}
+
+// Hours returns the length of the window in whole hours
+func (w Window) Hours() int {
+	return int(w.Until.Sub(w.Since).Hours())
+}
+
+// Days returns the number of calendar days the window touches
+func (w Window) Days() int {
+	since := time.Date(w.Since.Year(), w.Since.Month(), w.Since.Day(), 0, 0, 0, 0, time.UTC)
+	until := time.Date(w.Until.Year(), w.Until.Month(), w.Until.Day(), 0, 0, 0, 0, time.UTC)
+	return int(until.Sub(since).Hours()/24) + 1
+}
+
+// ActivityItem is a single piece of activity normalised across sources
+type ActivityItem struct {
+	Source     string
+	Kind       string
+	ID         string
+	Identifier string
+	Title      string
+	URL        string
+	Repo       string
+	Count      int
+	OccurredAt string
+}
+
+// SourceResult is what an ActivitySource returns for a window
+type SourceResult struct {
+	Items []ActivityItem
+	// Summary is a one-line, human readable description of what was found
+	Summary string
+	// Data carries the source-specific payload (e.g. GitHubActivity)
+	Data interface{}
+}
+
+// ActivitySource is an integration that can report the viewer's activity for a window
+type ActivitySource interface {
+	// Name is the key used for the source in config (e.g. "github")
+	Name() string
+	// Configured reports whether the source has the credentials it needs
+	Configured(config *viper.Viper) bool
+	// Fetch retrieves the viewer's activity within the window
+	Fetch(ctx context.Context, client *http.Client, window Window, config *viper.Viper) (SourceResult, error)
+}
+
+var registeredSources []ActivitySource
+
+// RegisterSource adds a source to the registry. Sources are fetched in registration order.
+func RegisterSource(source ActivitySource) {
+	for _, existing := range registeredSources {
+		if existing.Name() == source.Name() {
+			panic(fmt.Sprintf("activity source %q registered twice", source.Name()))
+		}
+	}
+	registeredSources = append(registeredSources, source)
+}
+
+// RegisteredSources returns every known source, in registration order
+func RegisteredSources() []ActivitySource {
+	return append([]ActivitySource(nil), registeredSources...)
+}
+
+// EnabledSources returns the sources that should be fetched for this run.
+// The "sources" config key restricts the list; it defaults to every registered source.
+// Sources without credentials are returned separately so callers can report them.
+func EnabledSources(config *viper.Viper) (enabled []ActivitySource, unconfigured []ActivitySource) {
+	wanted := config.GetStringSlice("sources")
+
+	for _, source := range registeredSources {
+		if len(wanted) > 0 && !containsFold(wanted, source.Name()) {
+			continue
+		}
+		if !source.Configured(config) {
+			unconfigured = append(unconfigured, source)
+			continue
+		}
+		enabled = append(enabled, source)
+	}
+
+	return enabled, unconfigured
+}
+
+// ItemsOfKind filters items down to a single kind
+func ItemsOfKind(items []ActivityItem, kind string) []ActivityItem {
+	var filtered []ActivityItem
+	for _, item := range items {
+		if item.Kind == kind {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(values map[string]int) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
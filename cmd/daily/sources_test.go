@@ -0,0 +1,110 @@
+package daily
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Helper function to serve a mock response file
+func newMockServer(t *testing.T, mockFile string) *httptest.Server {
+	mockResponseBytes, err := os.ReadFile(mockFile)
+	require.NoError(t, err, "Failed to read mock response file")
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(mockResponseBytes)
+	}))
+}
+
+// Test the built-in sources are registered in order
+func Test_RegisteredSources_BuiltIns(t *testing.T) {
+	assert.Equal(t, []string{"github", "linear"}, sourceNames(RegisteredSources()))
+}
+
+// Test sources without an API key are reported as unconfigured
+func Test_EnabledSources_SkipsUnconfigured(t *testing.T) {
+	// Arrange
+	config := createGitHubTestConfig("gh-token")
+
+	// Act
+	enabled, unconfigured := EnabledSources(config)
+
+	// Assert
+	assert.Equal(t, []string{"github"}, sourceNames(enabled))
+	assert.Equal(t, []string{"linear"}, sourceNames(unconfigured))
+}
+
+// Test the sources config key restricts which sources are fetched
+func Test_EnabledSources_RespectsConfig(t *testing.T) {
+	// Arrange
+	config := createTestConfig("https://api.linear.app/graphql", "linear-token")
+	config.Set("github.apiToken", "gh-token")
+	config.Set("sources", []string{"Linear"})
+
+	// Act
+	enabled, unconfigured := EnabledSources(config)
+
+	// Assert
+	assert.Equal(t, []string{"linear"}, sourceNames(enabled))
+	assert.Empty(t, unconfigured)
+}
+
+// Test registering a source twice panics
+func Test_RegisterSource_Duplicate(t *testing.T) {
+	assert.Panics(t, func() { RegisterSource(GitHubSource{}) })
+}
+
+// Test LinearSource normalises assigned issues into items
+func Test_LinearSource_Fetch(t *testing.T) {
+	// Arrange
+	mockServer := newMockServer(t, "../../mockResponses/response.json")
+	defer mockServer.Close()
+	config := createTestConfig(mockServer.URL, "test-token")
+	window := Window{Since: time.Now().Add(-48 * time.Hour), Until: time.Now()}
+
+	// Act
+	result, err := LinearSource{}.Fetch(context.Background(), &http.Client{}, window, config)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, result.Items, 2)
+	assert.Equal(t, ItemKindLinearIssue, result.Items[0].Kind)
+	assert.Equal(t, "test-issue-id-001", result.Items[0].ID)
+	assert.Equal(t, "2 issue(s) updated", result.Summary)
+}
+
+// Test GitHubActivity is flattened into items of each kind
+func Test_GitHubActivity_Items(t *testing.T) {
+	// Arrange
+	activity := GitHubActivity{
+		CommitsByRepo:        map[string]int{"testorg/b": 1, "testorg/a": 2},
+		PullRequestsCreated:  []GitHubPullRequest{{Title: "PR", URL: "https://github.com/testorg/a/pull/1", Number: 1, RepoOwner: "testorg", RepoName: "a"}},
+		PullRequestsReviewed: []GitHubPullRequest{{Title: "Review", URL: "https://github.com/testorg/b/pull/2", Number: 2, RepoOwner: "testorg", RepoName: "b"}},
+	}
+
+	// Act
+	items := activity.Items()
+
+	// Assert
+	require.Len(t, items, 4)
+	assert.Equal(t, "testorg/a#1", items[0].Identifier)
+	assert.Equal(t, ItemKindReview, items[1].Kind)
+	assert.Equal(t, "testorg/a", items[2].Repo)
+	assert.Equal(t, 2, items[2].Count)
+	assert.Len(t, ItemsOfKind(items, ItemKindCommits), 2)
+}
+
+// Test the Linear date filter is bounded by both ends of the window
+func Test_LinearDateFilter(t *testing.T) {
+	since := time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, 3, 16, 23, 59, 59, 0, time.UTC)
+	assert.Equal(t, `gte: "2025-03-10T00:00:00Z", lte: "2025-03-16T23:59:59Z"`, LinearDateFilter(Window{Since: since, Until: until}))
+}
@@ -0,0 +1,205 @@
+package daily
+
+import (
+	"bufio"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:embed templates/standup.md.tmpl
+var defaultStandupTemplate string
+
+// PlanItem is something planned for today: a Linear issue, a pull request or free text
+type PlanItem struct {
+	// Identifier is the Linear identifier or "owner/repo#number", empty for free text
+	Identifier string
+	Title      string
+	URL        string
+}
+
+func (p PlanItem) key() string {
+	if p.Identifier != "" {
+		return p.Identifier
+	}
+	return p.Title
+}
+
+// Blocker is something in the way, with the Linear issue it blocks if any
+type Blocker struct {
+	Identifier string
+	Title      string
+	URL        string
+	Reason     string
+}
+
+func (b Blocker) key() string {
+	return b.Identifier + " " + b.Reason
+}
+
+// Linear state types of issues that are not finished
+var openStateTypes = map[string]bool{"backlog": true, "unstarted": true, "started": true, "triage": true}
+
+// StandupSuggestions lists what is likely on today's plan: Linear issues in progress and
+// pull requests that are still open
+func StandupSuggestions(data SummaryData) []PlanItem {
+	var suggestions []PlanItem
+	seen := make(map[string]bool)
+	for _, issue := range standupIssues(data) {
+		if issue.State.Type == "started" && !seen[issue.Identifier] {
+			seen[issue.Identifier] = true
+			suggestions = append(suggestions, PlanItem{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL})
+		}
+	}
+	for _, pr := range data.GitHub.PullRequestsCreated {
+		identifier := fmt.Sprintf("%s/%s#%d", pr.RepoOwner, pr.RepoName, pr.Number)
+		if strings.EqualFold(pr.State, "open") && !seen[identifier] {
+			seen[identifier] = true
+			suggestions = append(suggestions, PlanItem{Identifier: identifier, Title: pr.Title, URL: pr.URL})
+		}
+	}
+	return suggestions
+}
+
+// BlockableIssues lists the Linear issues of the day that are not finished, which can be
+// marked as blocked
+func BlockableIssues(data SummaryData) []PlanItem {
+	var issues []PlanItem
+	seen := make(map[string]bool)
+	for _, issue := range standupIssues(data) {
+		if openStateTypes[issue.State.Type] && !seen[issue.Identifier] {
+			seen[issue.Identifier] = true
+			issues = append(issues, PlanItem{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL})
+		}
+	}
+	return issues
+}
+
+func standupIssues(data SummaryData) []LinearIssueDetails {
+	var issues []LinearIssueDetails
+	for _, issue := range data.Issues {
+		issues = append(issues, issue.Details)
+	}
+	return append(issues, data.NotWorked...)
+}
+
+// PromptForStandup asks for today's plan, offering the suggestions by number, and for
+// blockers. Marking one of the blockable issues as blocked asks for the reason.
+func PromptForStandup(input io.Reader, suggestions []PlanItem, blockable []PlanItem) ([]PlanItem, []Blocker) {
+	reader := bufio.NewReader(input)
+	var plan []PlanItem
+	var blockers []Blocker
+
+	fmt.Fprintln(console, "\n🗓️  What are you working on today?")
+	if len(suggestions) > 0 {
+		printPlanItems(suggestions)
+		fmt.Fprintln(console, "❓ Pick the ones on today's plan, e.g. \"1 3\" (Enter for none)")
+		fmt.Fprint(console, "▶ ")
+		response, err := reader.ReadString('\n')
+		for _, index := range parseSelection(response, len(suggestions)) {
+			plan = append(plan, suggestions[index])
+		}
+		if err != nil {
+			return plan, blockers
+		}
+	}
+
+	fmt.Fprintln(console, "❓ Anything else planned? (Press Enter on an empty line to finish)")
+	for {
+		text, err := readPromptLine(reader)
+		if text != "" {
+			plan = append(plan, PlanItem{Title: text})
+		}
+		if err != nil {
+			return plan, blockers
+		}
+		if text == "" {
+			break
+		}
+	}
+
+	fmt.Fprintln(console, "\n🚧 Anything blocking you?")
+	if len(blockable) > 0 {
+		printPlanItems(blockable)
+		fmt.Fprintln(console, "❓ Pick the issues that are blocked, e.g. \"2\" (Enter for none)")
+		fmt.Fprint(console, "▶ ")
+		response, err := reader.ReadString('\n')
+		for _, index := range parseSelection(response, len(blockable)) {
+			issue := blockable[index]
+			fmt.Fprintf(console, "❓ What is blocking %s?\n", issue.Identifier)
+			reason, reasonErr := readPromptLine(reader)
+			blockers = append(blockers, Blocker{Identifier: issue.Identifier, Title: issue.Title, URL: issue.URL, Reason: reason})
+			if reasonErr != nil {
+				return plan, blockers
+			}
+		}
+		if err != nil {
+			return plan, blockers
+		}
+	}
+
+	fmt.Fprintln(console, "❓ Any other blockers? (Press Enter on an empty line to finish)")
+	for {
+		text, err := readPromptLine(reader)
+		if text != "" {
+			blockers = append(blockers, Blocker{Reason: text})
+		}
+		if text == "" || err != nil {
+			return plan, blockers
+		}
+	}
+}
+
+func printPlanItems(items []PlanItem) {
+	for i, item := range items {
+		fmt.Fprintf(console, "   %d. %s %s\n", i+1, item.Identifier, item.Title)
+	}
+}
+
+func readPromptLine(reader *bufio.Reader) (string, error) {
+	fmt.Fprint(console, "▶ ")
+	line, err := reader.ReadString('\n')
+	return strings.TrimSpace(line), err
+}
+
+// parseSelection turns "1 3" or "1,3" into the zero-based indexes of a list of n items,
+// ignoring anything out of range
+func parseSelection(response string, n int) []int {
+	var indexes []int
+	seen := make(map[int]bool)
+	for _, field := range strings.FieldsFunc(response, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r' }) {
+		number, err := strconv.Atoi(field)
+		if err != nil || number < 1 || number > n || seen[number-1] {
+			continue
+		}
+		seen[number-1] = true
+		indexes = append(indexes, number-1)
+	}
+	return indexes
+}
+
+// promptForStandup runs PromptForStandup on stdin but returns nothing once ctx is cancelled
+func promptForStandup(ctx context.Context, data SummaryData) ([]PlanItem, []Blocker) {
+	plan, blockers := PromptForStandup(stdin.WithContext(ctx), StandupSuggestions(data), BlockableIssues(data))
+	if ctx.Err() != nil {
+		return nil, nil
+	}
+	return plan, blockers
+}
+
+// RenderStandup renders the data as a compact Yesterday / Today / Blockers update for chat,
+// with the template at templatePath or the built-in one
+func RenderStandup(w io.Writer, data SummaryData, templatePath string) error {
+	tmpl, err := loadTemplate(templatePath, "standup.md.tmpl", defaultStandupTemplate)
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render standup: %w", err)
+	}
+	return nil
+}
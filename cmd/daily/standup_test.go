@@ -0,0 +1,99 @@
+package daily
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleStandupData() SummaryData {
+	data := sampleSummaryData()
+	data.Issues[0].Details.State.Type = "started"
+	data.GitHub.PullRequestsCreated[0].State = "OPEN"
+	blocked := LinearIssueDetails{Identifier: "TEST-124", Title: "Rotate keys"}
+	blocked.State.Type = "unstarted"
+	done := LinearIssueDetails{Identifier: "TEST-125", Title: "Old bug"}
+	done.State.Type = "completed"
+	data.NotWorked = []LinearIssueDetails{blocked, done}
+	return data
+}
+
+// Test issues in progress and open pull requests are suggested, unfinished issues can be blocked
+func Test_StandupSuggestions(t *testing.T) {
+	data := sampleStandupData()
+
+	assert.Equal(t, []PlanItem{
+		{Identifier: "TEST-123", Title: "Implement auth", URL: "https://linear.app/test/issue/TEST-123"},
+		{Identifier: "testorg/mastercrab#10", Title: "feat: Add daily command", URL: "https://github.com/testorg/mastercrab/pull/10"},
+	}, StandupSuggestions(data))
+
+	blockable := BlockableIssues(data)
+	require.Len(t, blockable, 2)
+	assert.Equal(t, "TEST-124", blockable[1].Identifier)
+}
+
+// Test the plan is picked from the suggestions plus free text, and blocked issues ask for a reason
+func Test_PromptForStandup(t *testing.T) {
+	// Arrange
+	data := sampleStandupData()
+	input := strings.NewReader("2, 9 x\nPrepare the demo\n\n2\nWaiting on infra\nFlaky staging\n\n")
+
+	// Act
+	plan, blockers := PromptForStandup(input, StandupSuggestions(data), BlockableIssues(data))
+
+	// Assert
+	require.Len(t, plan, 2)
+	assert.Equal(t, "testorg/mastercrab#10", plan[0].Identifier)
+	assert.Equal(t, PlanItem{Title: "Prepare the demo"}, plan[1])
+	assert.Equal(t, []Blocker{
+		{Identifier: "TEST-124", Title: "Rotate keys", Reason: "Waiting on infra"},
+		{Reason: "Flaky staging"},
+	}, blockers)
+}
+
+// Test the standup is rendered compactly and its plan and blockers survive the export
+func Test_RenderStandup(t *testing.T) {
+	// Arrange
+	data := sampleStandupData()
+	data.Plan = []PlanItem{{Identifier: "TEST-123", Title: "Implement auth"}, {Title: "Prepare the demo"}}
+	data.Blockers = []Blocker{{Identifier: "TEST-124", Title: "Rotate keys", Reason: "Waiting on infra"}}
+
+	// Act
+	var out, export bytes.Buffer
+	require.NoError(t, WriteFormatted(&out, data, FormatStandup, ""))
+	require.NoError(t, WriteFormatted(&export, data, FormatJSON, ""))
+
+	// Assert
+	expected := `**Yesterday**
+- TEST-123 Implement auth: Added token refresh
+- testorg/mastercrab#10 feat: Add daily command
+- Reviewed testorg/mastercrab#5 fix: Handle empty responses
+- 5 commit(s) to testorg/mastercrab
+
+**Today**
+- TEST-123 Implement auth
+- Prepare the demo
+
+**Blockers**
+- TEST-124 Rotate keys: Waiting on infra
+`
+	assert.Equal(t, expected, out.String())
+
+	var decoded DailyExport
+	require.NoError(t, json.Unmarshal(export.Bytes(), &decoded))
+	assert.Equal(t, data.Plan, decoded.SummaryData().Plan)
+	assert.Equal(t, data.Blockers, decoded.SummaryData().Blockers)
+}
+
+// Test an empty standup still has its three sections
+func Test_RenderStandup_Empty(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, RenderStandup(&out, SummaryData{}, ""))
+
+	assert.Equal(t, "**Yesterday**\n- Nothing recorded\n\n**Today**\n- Nothing planned yet\n\n**Blockers**\n- None\n", out.String())
+}
@@ -0,0 +1,296 @@
+package daily
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultStatsWeeks is how many weeks "stats" covers when --since is not given
+const DefaultStatsWeeks = 12
+
+// statsBarWidth is the width of the longest bar of the weekly chart
+const statsBarWidth = 30
+
+// Stats are activity statistics over a range of days, see "crab stats"
+type Stats struct {
+	Since time.Time
+	Until time.Time
+	// Days is the number of days recorded by daily runs in the range
+	Days int
+	// Heatmap counts the commits, pull requests, reviews and issues by weekday and hour,
+	// Heatmap[time.Monday][9] being Mondays from 9:00 to 10:00
+	Heatmap [7][24]int
+	Weeks   []WeekStats
+	// PullRequests and Reviews are the totals of the weeks, from GitHub when it could be
+	// fetched and from the recorded days otherwise
+	PullRequests       int
+	Reviews            int
+	MergedPullRequests int
+	// MedianTimeToMerge is 0 when no merged pull request is known, merge times are only
+	// fetched from GitHub
+	MedianTimeToMerge time.Duration
+	// FromGitHub reports whether the GitHub contributions could be fetched
+	FromGitHub bool
+}
+
+// WeekStats is the activity of a week starting on Monday
+type WeekStats struct {
+	Start        time.Time
+	Commits      int
+	PullRequests int
+	Reviews      int
+}
+
+// ReviewRatio is the number of reviews per pull request opened, 0 without pull requests
+func (s Stats) ReviewRatio() float64 {
+	if s.PullRequests == 0 {
+		return 0
+	}
+	return float64(s.Reviews) / float64(s.PullRequests)
+}
+
+// BuildStats computes the statistics of the days recorded between since and until, with
+// the GitHub contributions of the same period when they could be fetched. Times are
+// placed in the heatmap and weeks in loc.
+//
+// Every item is counted once, at the time it happened, even when the windows of several
+// runs saw it. The fetched contributions replace the recorded pull requests and reviews,
+// and the recorded commits when they are known per day, so the weekly chart adds up to
+// the totals below it.
+func BuildStats(since time.Time, until time.Time, days []DailyExport, contributions *GitHubContributions, loc *time.Location) Stats {
+	if loc == nil {
+		loc = time.Local
+	}
+	stats := Stats{Since: since, Until: until, Days: len(days)}
+
+	weeks := make(map[string]int)
+	for start := WeekStart(since.In(loc)); !start.After(until); start = start.AddDate(0, 0, 7) {
+		weeks[DayKey(start)] = len(stats.Weeks)
+		stats.Weeks = append(stats.Weeks, WeekStats{Start: start})
+	}
+	week := func(at time.Time) *WeekStats {
+		if i, ok := weeks[DayKey(WeekStart(at))]; ok {
+			return &stats.Weeks[i]
+		}
+		return nil
+	}
+
+	// Runs on consecutive days can both see an item, it only counts once
+	seen := make(map[string]bool)
+	count := func(value string, key string, add func(week *WeekStats)) {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil || seen[key+" "+value] {
+			return
+		}
+		seen[key+" "+value] = true
+		at = at.In(loc)
+		stats.Heatmap[at.Weekday()][at.Hour()]++
+		if w := week(at); w != nil && add != nil {
+			add(w)
+		}
+	}
+	addCommit := func(w *WeekStats) { w.Commits++ }
+	addPullRequest := func(w *WeekStats) { w.PullRequests++ }
+	addReview := func(w *WeekStats) { w.Reviews++ }
+
+	commitDays := contributions != nil && len(contributions.CommitDays) > 0
+	if commitDays {
+		// Commits per day have no time of day, they only count towards the weeks
+		addCommit = nil
+		for _, day := range contributions.CommitDays {
+			if at, err := time.Parse(time.RFC3339, day.Day); err == nil {
+				if w := week(at.In(loc)); w != nil {
+					w.Commits += day.Commits
+				}
+			}
+		}
+	}
+
+	for _, day := range days {
+		for _, commit := range day.GitHub.CommitHeadlines {
+			count(commit.CommittedAt, commit.URL, addCommit)
+		}
+		if contributions == nil {
+			for _, pr := range day.GitHub.PullRequests {
+				count(pr.OccurredAt, pr.URL, addPullRequest)
+			}
+			for _, pr := range day.GitHub.Reviews {
+				count(pr.OccurredAt, "review "+pr.URL, addReview)
+			}
+		}
+		for _, issue := range day.GitHub.Issues {
+			count(issue.OccurredAt, issue.URL, nil)
+		}
+	}
+
+	if contributions != nil {
+		stats.FromGitHub = true
+		for _, pr := range contributions.PullRequests {
+			count(pr.OccurredAt, pr.URL, addPullRequest)
+		}
+		for _, pr := range contributions.Reviews {
+			count(pr.OccurredAt, "review "+pr.URL, addReview)
+		}
+
+		var timesToMerge []time.Duration
+		for _, pr := range contributions.PullRequests {
+			opened, openedErr := time.Parse(time.RFC3339, pr.OccurredAt)
+			merged, mergedErr := time.Parse(time.RFC3339, pr.MergedAt)
+			if openedErr == nil && mergedErr == nil && !merged.Before(opened) {
+				timesToMerge = append(timesToMerge, merged.Sub(opened))
+			}
+		}
+		stats.MergedPullRequests = len(timesToMerge)
+		stats.MedianTimeToMerge = median(timesToMerge)
+	}
+
+	for _, week := range stats.Weeks {
+		stats.PullRequests += week.PullRequests
+		stats.Reviews += week.Reviews
+	}
+
+	return stats
+}
+
+func median(durations []time.Duration) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// heatLevels are the glyphs and colors of the heatmap, from no activity to the busiest hour
+var heatLevels = []struct {
+	glyph string
+	style lipgloss.Style
+}{
+	{"·", helpStyle},
+	{"░", lipgloss.NewStyle().Foreground(lipgloss.Color("22"))},
+	{"▒", lipgloss.NewStyle().Foreground(lipgloss.Color("28"))},
+	{"▓", lipgloss.NewStyle().Foreground(lipgloss.Color("34"))},
+	{"█", lipgloss.NewStyle().Foreground(lipgloss.Color("40"))},
+}
+
+var (
+	statsTitleStyle   = lipgloss.NewStyle().Bold(true)
+	commitsBarStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("34"))
+	reviewsBarStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("33"))
+	statsWeekdayOrder = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}
+)
+
+// heatCell draws a heatmap cell, darker the closer count is to the busiest one
+func heatCell(count int, busiest int) string {
+	level := 0
+	if count > 0 && busiest > 0 {
+		level = (count*(len(heatLevels)-1) + busiest - 1) / busiest
+	}
+	return heatLevels[level].style.Render(strings.Repeat(heatLevels[level].glyph, 2))
+}
+
+// RenderStats writes the statistics for the terminal: the heatmap of activity by day of
+// week and hour, commits and reviews per week, and pull request figures
+func RenderStats(w io.Writer, stats Stats, loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	fmt.Fprintln(w, statsTitleStyle.Render(fmt.Sprintf("📊 Activity from %s to %s (%d recorded day(s))",
+		stats.Since.Format("Mon Jan 2, 2006"), stats.Until.Format("Mon Jan 2, 2006"), stats.Days)))
+
+	// Heatmap, with the totals per day of week on the right and per hour at the bottom
+	var byHour [24]int
+	var byDay [7]int
+	busiest, busiestHour, busiestDay := 0, 0, 0
+	for day := range stats.Heatmap {
+		for hour, count := range stats.Heatmap[day] {
+			byHour[hour] += count
+			byDay[day] += count
+			busiest = max(busiest, count)
+		}
+	}
+	for _, count := range byHour {
+		busiestHour = max(busiestHour, count)
+	}
+	for _, count := range byDay {
+		busiestDay = max(busiestDay, count)
+	}
+
+	fmt.Fprintln(w, "\n"+statsTitleStyle.Render(fmt.Sprintf("🔥 Activity by day of week and hour (%s)", loc)))
+	fmt.Fprint(w, "\n       ")
+	var hours []string
+	for hour := 0; hour < 24; hour += 3 {
+		hours = append(hours, fmt.Sprintf("%-6d", hour))
+	}
+	fmt.Fprintln(w, strings.TrimSpace(strings.Join(hours, "")))
+	for _, day := range statsWeekdayOrder {
+		fmt.Fprintf(w, "  %s  ", day.String()[:3])
+		for _, count := range stats.Heatmap[day] {
+			fmt.Fprint(w, heatCell(count, busiest))
+		}
+		fmt.Fprintf(w, "  %s %d\n", heatCell(byDay[day], busiestDay), byDay[day])
+	}
+	fmt.Fprint(w, "  All  ")
+	for _, count := range byHour {
+		fmt.Fprint(w, heatCell(count, busiestHour))
+	}
+	fmt.Fprintln(w)
+
+	// Weekly chart, both bars on the same scale
+	fmt.Fprintln(w, "\n"+statsTitleStyle.Render("📈 Commits and reviews per week"))
+	fmt.Fprintln(w)
+	widest := 0
+	for _, week := range stats.Weeks {
+		widest = max(widest, week.Commits, week.Reviews)
+	}
+	bar := func(count int) int {
+		if widest == 0 || count == 0 {
+			return 0
+		}
+		return max(1, count*statsBarWidth/widest)
+	}
+	for _, week := range stats.Weeks {
+		fmt.Fprintf(w, "  %s  commits %s %d\n", week.Start.Format("Jan 02"), commitsBarStyle.Render(strings.Repeat("█", bar(week.Commits))), week.Commits)
+		fmt.Fprintf(w, "          reviews %s %d\n", reviewsBarStyle.Render(strings.Repeat("█", bar(week.Reviews))), week.Reviews)
+	}
+
+	fmt.Fprintln(w, "\n"+statsTitleStyle.Render("🔀 Pull requests and reviews"))
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  Pull requests opened: %d\n", stats.PullRequests)
+	fmt.Fprintf(w, "  Reviews: %d", stats.Reviews)
+	if stats.PullRequests > 0 {
+		fmt.Fprintf(w, " (%.1f per pull request opened)", stats.ReviewRatio())
+	}
+	fmt.Fprintln(w)
+	switch {
+	case !stats.FromGitHub:
+		fmt.Fprintln(w, "  Median time to merge: unknown, merge times are fetched from GitHub")
+	case stats.MergedPullRequests == 0:
+		fmt.Fprintln(w, "  Median time to merge: no pull request was merged")
+	default:
+		fmt.Fprintf(w, "  Median time to merge: %s (%d merged)\n", FormatTimeToMerge(stats.MedianTimeToMerge), stats.MergedPullRequests)
+	}
+}
+
+// FormatTimeToMerge writes how long a pull request stayed open, e.g. 45m, 3h20 or 2d 4h
+func FormatTimeToMerge(open time.Duration) string {
+	if open < 24*time.Hour {
+		return FormatTimeSpent(open)
+	}
+	days := int(open / (24 * time.Hour))
+	hours := int(open % (24 * time.Hour) / time.Hour)
+	if hours == 0 {
+		return fmt.Sprintf("%dd", days)
+	}
+	return fmt.Sprintf("%dd %dh", days, hours)
+}
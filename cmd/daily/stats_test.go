@@ -0,0 +1,137 @@
+package daily
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func statsDays() []DailyExport {
+	thursday := DailyExport{GeneratedAt: time.Date(2026, 10, 15, 18, 0, 0, 0, time.UTC)}
+	thursday.GitHub.Totals = ExportGitHubTotals{Commits: 4, PullRequests: 1, Reviews: 2}
+	thursday.GitHub.CommitHeadlines = []ExportCommit{
+		{Repo: "acme/api", Headline: "Add retries", URL: "https://github.com/acme/api/commit/1", CommittedAt: "2026-10-15T09:10:00Z"},
+		{Repo: "acme/api", Headline: "Fix retries", URL: "https://github.com/acme/api/commit/2", CommittedAt: "2026-10-15T09:50:00Z"},
+	}
+	thursday.GitHub.PullRequests = []ExportPullRequest{{Repo: "acme/api", Number: 12, URL: "https://github.com/acme/api/pull/12", OccurredAt: "2026-10-15T10:00:00Z"}}
+
+	// A 48 hour run on Friday sees Thursday's commit and pull request again, they count once
+	friday := DailyExport{GeneratedAt: time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)}
+	friday.GitHub.Totals = ExportGitHubTotals{Commits: 2, PullRequests: 1, Reviews: 1}
+	friday.GitHub.CommitHeadlines = thursday.GitHub.CommitHeadlines[1:]
+	friday.GitHub.PullRequests = thursday.GitHub.PullRequests
+	friday.GitHub.Reviews = []ExportPullRequest{{Repo: "acme/web", Number: 3, URL: "https://github.com/acme/web/pull/3", OccurredAt: "2026-10-16T22:30:00Z"}}
+
+	earlier := DailyExport{GeneratedAt: time.Date(2026, 10, 6, 18, 0, 0, 0, time.UTC)}
+	earlier.GitHub.Totals = ExportGitHubTotals{Commits: 1, PullRequests: 2, Reviews: 1}
+	earlier.GitHub.CommitHeadlines = []ExportCommit{{Repo: "acme/api", URL: "https://github.com/acme/api/commit/0", CommittedAt: "2026-10-06T08:00:00Z"}}
+	earlier.GitHub.PullRequests = []ExportPullRequest{
+		{Repo: "acme/api", Number: 10, URL: "https://github.com/acme/api/pull/10", OccurredAt: "2026-10-06T11:00:00Z"},
+		{Repo: "acme/api", Number: 11, URL: "https://github.com/acme/api/pull/11", OccurredAt: "2026-10-06T15:00:00Z"},
+	}
+	earlier.GitHub.Reviews = []ExportPullRequest{{Repo: "acme/web", Number: 2, URL: "https://github.com/acme/web/pull/2", OccurredAt: "2026-10-06T16:00:00Z"}}
+	return []DailyExport{earlier, thursday, friday}
+}
+
+// Test activity is counted by weekday and hour, and commits and reviews by week
+func Test_BuildStats(t *testing.T) {
+	// Arrange
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	stats := BuildStats(since, until, statsDays(), nil, time.UTC)
+
+	// Assert
+	assert.Equal(t, 3, stats.Days)
+	assert.Equal(t, 3, stats.Heatmap[time.Thursday][9]+stats.Heatmap[time.Thursday][10])
+	assert.Equal(t, 2, stats.Heatmap[time.Thursday][9])
+	assert.Equal(t, 1, stats.Heatmap[time.Friday][22])
+
+	require.Len(t, stats.Weeks, 3)
+	assert.Equal(t, time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), stats.Weeks[0].Start)
+	assert.Equal(t, WeekStats{Start: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), Commits: 1, PullRequests: 2, Reviews: 1}, stats.Weeks[1])
+	assert.Equal(t, WeekStats{Start: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Commits: 2, PullRequests: 1, Reviews: 1}, stats.Weeks[2])
+
+	assert.Equal(t, 3, stats.PullRequests)
+	assert.Equal(t, 2, stats.Reviews)
+	assert.InDelta(t, 0.67, stats.ReviewRatio(), 0.01)
+	assert.False(t, stats.FromGitHub)
+	assert.Zero(t, stats.MedianTimeToMerge)
+}
+
+// Test pull request figures and merge times come from GitHub when it was fetched
+func Test_BuildStats_GitHub(t *testing.T) {
+	// Arrange
+	contributions := &GitHubContributions{
+		ReviewsByRepo: map[string]int{"acme/api": 3, "acme/web": 2},
+		PullRequests: []GitHubPullRequest{
+			{URL: "https://github.com/acme/api/pull/1", OccurredAt: "2026-10-01T10:00:00Z", MergedAt: "2026-10-01T14:00:00Z"},
+			{URL: "https://github.com/acme/api/pull/2", OccurredAt: "2026-10-02T10:00:00Z", MergedAt: "2026-10-04T10:00:00Z"},
+			{URL: "https://github.com/acme/api/pull/3", OccurredAt: "2026-10-05T10:00:00Z", MergedAt: "2026-10-05T11:00:00Z"},
+			{URL: "https://github.com/acme/api/pull/4", OccurredAt: "2026-10-06T10:00:00Z", MergedAt: "2026-10-06T20:00:00Z"},
+			{URL: "https://github.com/acme/api/pull/5", OccurredAt: "2026-10-07T10:00:00Z"},
+		},
+		Reviews: []GitHubPullRequest{
+			{URL: "https://github.com/acme/web/pull/1", OccurredAt: "2026-10-01T16:00:00Z"},
+			{URL: "https://github.com/acme/web/pull/1", OccurredAt: "2026-10-02T16:00:00Z"},
+			{URL: "https://github.com/acme/web/pull/2", OccurredAt: "2026-10-06T16:00:00Z"},
+			{URL: "https://github.com/acme/web/pull/3", OccurredAt: "2026-10-13T16:00:00Z"},
+			{URL: "https://github.com/acme/web/pull/4", OccurredAt: "2026-10-14T16:00:00Z"},
+		},
+		CommitDays: []GitHubCommitDay{
+			{Repo: "acme/api", Day: "2026-10-06T00:00:00Z", Commits: 7},
+			{Repo: "acme/web", Day: "2026-10-06T00:00:00Z", Commits: 2},
+			{Repo: "acme/api", Day: "2026-10-15T00:00:00Z", Commits: 4},
+		},
+	}
+
+	// Act
+	stats := BuildStats(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), statsDays(), contributions, time.UTC)
+
+	// Assert
+	assert.True(t, stats.FromGitHub)
+	assert.Equal(t, 5, stats.PullRequests)
+	assert.Equal(t, 5, stats.Reviews)
+	assert.Equal(t, 1.0, stats.ReviewRatio())
+
+	require.Len(t, stats.Weeks, 3)
+	assert.Equal(t, WeekStats{Start: time.Date(2026, 9, 28, 0, 0, 0, 0, time.UTC), PullRequests: 2, Reviews: 2}, stats.Weeks[0])
+	assert.Equal(t, WeekStats{Start: time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC), Commits: 9, PullRequests: 3, Reviews: 1}, stats.Weeks[1])
+	assert.Equal(t, WeekStats{Start: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Commits: 4, Reviews: 2}, stats.Weeks[2])
+	assert.Equal(t, 1, stats.Heatmap[time.Wednesday][16], "GitHub reviews are placed in the heatmap")
+	assert.Equal(t, 2, stats.Heatmap[time.Thursday][9], "recorded commits are still placed in the heatmap")
+	assert.Zero(t, stats.Heatmap[time.Friday][22], "recorded reviews are replaced by GitHub's")
+	assert.Equal(t, 4, stats.MergedPullRequests)
+	assert.Equal(t, 7*time.Hour, stats.MedianTimeToMerge)
+}
+
+// Test the terminal view shows the heatmap, the weekly chart and the pull request figures
+func Test_RenderStats(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	stats := BuildStats(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), statsDays()[1:], nil, time.UTC)
+
+	// Act
+	RenderStats(&out, stats, time.UTC)
+
+	// Assert
+	assert.Contains(t, out.String(), "📊 Activity from Mon Oct 12, 2026 to Fri Oct 16, 2026 (2 recorded day(s))")
+	assert.Contains(t, out.String(), "Activity by day of week and hour (UTC)")
+	assert.Contains(t, out.String(), "  Thu  ··················██▒▒····")
+	assert.Contains(t, out.String(), "  Oct 12  commits ██████████████████████████████ 2\n")
+	assert.Contains(t, out.String(), "          reviews ███████████████ 1\n")
+	assert.Contains(t, out.String(), "  Reviews: 1 (1.0 per pull request opened)\n")
+	assert.Contains(t, out.String(), "merge times are fetched from GitHub")
+}
+
+// Test times to merge are written in days and hours past a day
+func Test_FormatTimeToMerge(t *testing.T) {
+	assert.Equal(t, "45m", FormatTimeToMerge(45*time.Minute))
+	assert.Equal(t, "3h20", FormatTimeToMerge(3*time.Hour+20*time.Minute))
+	assert.Equal(t, "2d", FormatTimeToMerge(48*time.Hour))
+	assert.Equal(t, "2d 4h", FormatTimeToMerge(52*time.Hour+10*time.Minute))
+}
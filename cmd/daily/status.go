@@ -0,0 +1,92 @@
+package daily
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ExitPartial is the exit code of a run that produced a summary with some sources missing
+const ExitPartial = 2
+
+// Source report states
+const (
+	SourceOK      = "ok"
+	SourceFailed  = "failed"
+	SourceSkipped = "skipped"
+)
+
+// SourceReport describes how a single source fared during a run
+type SourceReport struct {
+	Name   string
+	Status string
+	Error  string
+	Items  int
+}
+
+// SourceStatus records a source, or part of one, that could not be fetched
+type SourceStatus struct {
+	Source string
+	Reason string
+}
+
+// PartialRunError is returned when the summary was produced without every source
+type PartialRunError struct {
+	Unavailable []SourceStatus
+}
+
+func (e *PartialRunError) Error() string {
+	names := make([]string, len(e.Unavailable))
+	for i, status := range e.Unavailable {
+		names[i] = status.Source
+	}
+	return fmt.Sprintf("partial run, unavailable: %s", strings.Join(names, ", "))
+}
+
+// ExitCode is the process exit code for a partial run
+func (e *PartialRunError) ExitCode() int {
+	return ExitPartial
+}
+
+// NothingFetchedError is returned when every source failed and nothing was written. It
+// carries no exit code of its own, status 2 is kept for partial summaries.
+func NothingFetchedError(unavailable []SourceStatus) error {
+	names := make([]string, len(unavailable))
+	for i, status := range unavailable {
+		names[i] = status.Source
+	}
+	return fmt.Errorf("no activity could be fetched, unavailable: %s", strings.Join(names, ", "))
+}
+
+// UnavailableSources lists every fetch that failed
+func UnavailableSources(fetches []SourceFetch) []SourceStatus {
+	var unavailable []SourceStatus
+	for _, fetch := range fetches {
+		if fetch.Err != nil {
+			unavailable = append(unavailable, SourceStatus{Source: fetch.Source.Name(), Reason: fetch.Err.Error()})
+		}
+	}
+	return unavailable
+}
+
+// SourceReports describes every source considered for the run, in registration order
+func SourceReports(fetches []SourceFetch, unconfigured []ActivitySource) []SourceReport {
+	byName := make(map[string]SourceReport)
+	for _, fetch := range fetches {
+		report := SourceReport{Name: fetch.Source.Name(), Status: SourceOK, Items: len(fetch.Result.Items)}
+		if fetch.Err != nil {
+			report = SourceReport{Name: fetch.Source.Name(), Status: SourceFailed, Error: fetch.Err.Error()}
+		}
+		byName[report.Name] = report
+	}
+	for _, source := range unconfigured {
+		byName[source.Name()] = SourceReport{Name: source.Name(), Status: SourceSkipped, Error: "no API key configured"}
+	}
+
+	var reports []SourceReport
+	for _, source := range registeredSources {
+		if report, ok := byName[source.Name()]; ok {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
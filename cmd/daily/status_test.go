@@ -0,0 +1,67 @@
+package daily
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test only failed fetches are reported as unavailable
+func Test_UnavailableSources(t *testing.T) {
+	// Arrange
+	fetches := []SourceFetch{
+		{Source: GitHubSource{}},
+		{Source: LinearSource{}, Err: fmt.Errorf("timed out after 30s")},
+	}
+
+	// Act
+	unavailable := UnavailableSources(fetches)
+
+	// Assert
+	assert.Equal(t, []SourceStatus{{Source: "linear", Reason: "timed out after 30s"}}, unavailable)
+}
+
+// Test a partial run carries its own exit code
+func Test_PartialRunError_ExitCode(t *testing.T) {
+	var err error = &PartialRunError{Unavailable: []SourceStatus{{Source: "linear", Reason: "boom"}}}
+
+	var coded interface{ ExitCode() int }
+	require.True(t, errors.As(err, &coded))
+	assert.Equal(t, ExitPartial, coded.ExitCode())
+	assert.Equal(t, "partial run, unavailable: linear", err.Error())
+}
+
+// Test a run that fetched nothing exits with the generic status
+func Test_NothingFetchedError_NoExitCode(t *testing.T) {
+	err := NothingFetchedError([]SourceStatus{{Source: "linear", Reason: "boom"}, {Source: "github", Reason: "boom"}})
+
+	var coded interface{ ExitCode() int }
+	assert.False(t, errors.As(err, &coded))
+	assert.Equal(t, "no activity could be fetched, unavailable: linear, github", err.Error())
+}
+
+// Test the summary keeps GitHub activity and lists unavailable sources
+func Test_GenerateSimplifiedMarkdownSummary_Unavailable(t *testing.T) {
+	// Arrange
+	filename := filepath.Join(t.TempDir(), "summary.md")
+	activity := GitHubActivity{
+		TotalPullRequests:   1,
+		PullRequestsCreated: []GitHubPullRequest{{Title: "Add feature", URL: "https://github.com/o/r/pull/1", Number: 1, RepoOwner: "o", RepoName: "r"}},
+	}
+	unavailable := []SourceStatus{{Source: "linear", Reason: "error querying Linear's API: timeout"}}
+
+	// Act
+	err := GenerateSimplifiedMarkdownSummary(SummaryData{GitHub: activity, Unavailable: unavailable}, "", filename)
+
+	// Assert
+	require.NoError(t, err)
+	content, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "- [o/r#1: Add feature](https://github.com/o/r/pull/1)")
+	assert.Contains(t, string(content), "## Sources unavailable\n\n- linear: error querying Linear's API: timeout\n")
+}
@@ -2,7 +2,9 @@ package daily
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -10,80 +12,92 @@ import (
 	"github.com/charmbracelet/glamour"
 )
 
+// console receives progress output and prompts. It is switched to stderr when
+// the summary itself is written to stdout.
+var console io.Writer = os.Stdout
+
 // IssueWithNotes stores an issue's details along with user notes
 type IssueWithNotes struct {
 	Details   LinearIssueDetails
 	UserNotes string
+	// TimeSpent is the time the user said they spent on the issue, 0 when not asked
+	TimeSpent time.Duration
 }
 
 // DisplayIssueDetails shows a formatted view of the issue details
 func DisplayIssueDetails(issue LinearIssueDetails) {
-	fmt.Println("\n" + strings.Repeat("═", 80))
-	fmt.Printf("📋 %s: %s\n", issue.Identifier, issue.Title)
-	fmt.Println(strings.Repeat("═", 80))
+	RenderIssueDetails(console, issue, 80, glamour.WithAutoStyle())
+}
 
-	fmt.Printf("\n🔗 URL: %s\n", issue.URL)
-	fmt.Printf("📊 State: %s (%s)\n", issue.State.Name, issue.State.Type)
-	fmt.Printf("⚡ Priority: %s\n", issue.PriorityLabel)
-	fmt.Printf("🕛 Created at: %s\n", issue.CreatedAt)
-	fmt.Printf("🕞 Updated at: %s\n", issue.UpdatedAt)
+// RenderIssueDetails writes the formatted view of the issue details, wrapped to width.
+// style selects the glamour style used for the description and comments.
+func RenderIssueDetails(w io.Writer, issue LinearIssueDetails, width int, style glamour.TermRendererOption) {
+	fmt.Fprintln(w, "\n"+strings.Repeat("═", width))
+	fmt.Fprintf(w, "📋 %s: %s\n", issue.Identifier, issue.Title)
+	fmt.Fprintln(w, strings.Repeat("═", width))
+
+	fmt.Fprintf(w, "\n🔗 URL: %s\n", issue.URL)
+	fmt.Fprintf(w, "📊 State: %s (%s)\n", issue.State.Name, issue.State.Type)
+	fmt.Fprintf(w, "⚡ Priority: %s\n", issue.PriorityLabel)
+	fmt.Fprintf(w, "🕛 Created at: %s\n", issue.CreatedAt)
+	fmt.Fprintf(w, "🕞 Updated at: %s\n", issue.UpdatedAt)
 
 	if issue.Assignee.Name != "" {
-		fmt.Printf("👤 Assignee: %s\n", issue.Assignee.Name)
+		fmt.Fprintf(w, "👤 Assignee: %s\n", issue.Assignee.Name)
 	}
 
 	// Display labels
 	if len(issue.Labels.Nodes) > 0 {
-		fmt.Printf("🏷️  Labels: ")
+		fmt.Fprintf(w, "🏷️  Labels: ")
 		labelNames := make([]string, len(issue.Labels.Nodes))
 		for i, label := range issue.Labels.Nodes {
 			labelNames[i] = label.Name
 		}
-		fmt.Println(strings.Join(labelNames, ", "))
+		fmt.Fprintln(w, strings.Join(labelNames, ", "))
 	}
 
 	// Display full description with markdown rendering
 	if issue.Description != "" {
-		fmt.Println("\n📝 Description:")
-		fmt.Println(strings.Repeat("─", 80))
+		fmt.Fprintln(w, "\n📝 Description:")
+		fmt.Fprintln(w, strings.Repeat("─", width))
 
 		// Render markdown in terminal using glamour
 		renderer, err := glamour.NewTermRenderer(
-			glamour.WithAutoStyle(),
-			glamour.WithWordWrap(80),
+			style,
+			glamour.WithWordWrap(width),
 		)
 
 		if err != nil {
 			// Fallback to plain text if glamour fails
-			fmt.Println(issue.Description)
+			fmt.Fprintln(w, issue.Description)
 		} else {
 			rendered, err := renderer.Render(issue.Description)
 			if err != nil {
 				// Fallback to plain text
-				fmt.Println(issue.Description)
+				fmt.Fprintln(w, issue.Description)
 			} else {
-				fmt.Print(rendered)
+				fmt.Fprint(w, rendered)
 			}
 		}
-		fmt.Println(strings.Repeat("─", 80))
+		fmt.Fprintln(w, strings.Repeat("─", width))
 	}
 
 	// Display recent comments
 	if len(issue.Comments.Nodes) > 0 {
-		fmt.Printf("\n💬 Comments (%d total):\n", len(issue.Comments.Nodes))
+		fmt.Fprintf(w, "\n💬 Comments (%d total):\n", len(issue.Comments.Nodes))
 		// Show only last 10 comments
 		start := 0
 		if len(issue.Comments.Nodes) > 10 {
 			start = len(issue.Comments.Nodes) - 10
-			fmt.Printf("   (Showing last 10 comments)\n")
+			fmt.Fprintf(w, "   (Showing last 10 comments)\n")
 		}
 		for i := start; i < len(issue.Comments.Nodes); i++ {
 			comment := issue.Comments.Nodes[i]
-			fmt.Printf("\n  💭 %s: %s \n", comment.User.Name, comment.UpdatedAt)
+			fmt.Fprintf(w, "\n  💭 %s: %s \n", comment.User.Name, comment.UpdatedAt)
 			// Render comment body as markdown too
 			renderer, err := glamour.NewTermRenderer(
-				glamour.WithAutoStyle(),
-				glamour.WithWordWrap(76),
+				style,
+				glamour.WithWordWrap(width-4),
 			)
 			if err == nil {
 				rendered, err := renderer.Render(comment.Body)
@@ -91,26 +105,65 @@ func DisplayIssueDetails(issue LinearIssueDetails) {
 					// Indent the rendered comment
 					lines := strings.Split(strings.TrimRight(rendered, "\n"), "\n")
 					for _, line := range lines {
-						fmt.Printf("     %s\n", line)
+						fmt.Fprintf(w, "     %s\n", line)
 					}
 				} else {
-					fmt.Printf("     %s\n", comment.Body)
+					fmt.Fprintf(w, "     %s\n", comment.Body)
 				}
 			} else {
-				fmt.Printf("     %s\n", comment.Body)
+				fmt.Fprintf(w, "     %s\n", comment.Body)
 			}
 		}
 	}
 
-	fmt.Println()
+	fmt.Fprintln(w)
 }
 
 // PromptForNotes prompts the user to add notes about their work on this issue
 func PromptForNotes(issue LinearIssueDetails) (string, bool, error) {
-	reader := bufio.NewReader(os.Stdin)
+	return PromptForNotesWithDefault(stdin.WithContext(context.Background()), issue, "", false)
+}
+
+// PromptForNotesWithDefault prompts for notes, offering notes from an earlier run as the default.
+// An empty answer keeps the earlier notes; anything typed is added to them.
+// With useEditor the notes are composed in $VISUAL/$EDITOR, falling back to inline input.
+func PromptForNotesWithDefault(input io.Reader, issue LinearIssueDetails, previousNotes string, useEditor bool) (string, bool, error) {
+	var compose func(previousNotes string) (string, error)
+	if useEditor {
+		compose = func(previousNotes string) (string, error) {
+			return ComposeNotesInEditor(issue, previousNotes)
+		}
+	}
+	return promptForAnswer(input, "Did you work on this issue today?", "Please describe what you did on this issue:", previousNotes, compose)
+}
 
-	fmt.Println("\n❓ Did you work on this issue today? (y/n/skip)")
-	fmt.Print("▶ ")
+// PromptForItemNotes asks whether a GitHub item belongs in the summary and for notes about it.
+// It returns false for items to leave out, and an error when the user skipped the item,
+// which keeps it in the summary without notes.
+func PromptForItemNotes(input io.Reader, item ActivityItem, previousNotes string, useEditor bool) (string, bool, error) {
+	var compose func(previousNotes string) (string, error)
+	if useEditor {
+		compose = func(previousNotes string) (string, error) {
+			return ComposeItemNotesInEditor(item, previousNotes)
+		}
+	}
+	return promptForAnswer(input, "Include this in the summary?", "Add notes about it, e.g. why it mattered:", previousNotes, compose)
+}
+
+// promptForAnswer asks a y/n/skip question, then for notes when the answer is yes.
+// compose, when set, writes the notes in an editor instead of line by line.
+func promptForAnswer(input io.Reader, question string, describe string, previousNotes string, compose func(previousNotes string) (string, error)) (string, bool, error) {
+	reader := bufio.NewReader(input)
+
+	if previousNotes != "" {
+		fmt.Fprintln(console, "\n📝 Your notes from earlier today:")
+		for _, line := range noteLines(previousNotes) {
+			fmt.Fprintf(console, "   %s\n", line)
+		}
+	}
+
+	fmt.Fprintf(console, "\n❓ %s (y/n/skip)\n", question)
+	fmt.Fprint(console, "▶ ")
 
 	response, err := reader.ReadString('\n')
 	if err != nil {
@@ -127,9 +180,20 @@ func PromptForNotes(issue LinearIssueDetails) (string, bool, error) {
 		return "", false, fmt.Errorf("user skipped")
 	}
 
-	fmt.Println("\n✍️  Please describe what you did on this issue:")
-	fmt.Println("   (Press Enter on an empty line to finish)")
-	fmt.Print("▶ ")
+	if compose != nil {
+		notes, err := compose(previousNotes)
+		if err == nil {
+			return notes, true, nil
+		}
+		fmt.Fprintf(console, "⚠️  Could not use your editor (%s), falling back to inline notes\n", err)
+	}
+
+	fmt.Fprintf(console, "\n✍️  %s\n", describe)
+	if previousNotes != "" {
+		fmt.Fprintln(console, "   (Press Enter right away to keep your earlier notes)")
+	}
+	fmt.Fprintln(console, "   (Press Enter on an empty line to finish)")
+	fmt.Fprint(console, "▶ ")
 
 	var notes strings.Builder
 	for {
@@ -149,10 +213,18 @@ func PromptForNotes(issue LinearIssueDetails) (string, bool, error) {
 			notes.WriteString("\n")
 		}
 		notes.WriteString(line)
-		fmt.Print("▶ ")
+		fmt.Fprint(console, "▶ ")
 	}
 
-	return notes.String(), true, nil
+	return MergeNotes(previousNotes, notes.String()), true, nil
+}
+
+// DisplayItemDetails shows a GitHub item before asking about it
+func DisplayItemDetails(item ActivityItem) {
+	var b strings.Builder
+	fmt.Fprintln(&b)
+	renderActivityItem(&b, item, 80)
+	fmt.Fprint(console, b.String())
 }
 
 // GenerateMarkdownSummary creates a markdown summary of the daily work
@@ -208,87 +280,19 @@ func GenerateMarkdownSummary(issuesWithNotes []IssueWithNotes, filename string)
 	return nil
 }
 
-// GenerateSimplifiedMarkdownSummary creates a simplified markdown summary with GitHub activity
-func GenerateSimplifiedMarkdownSummary(issuesWithNotes []IssueWithNotes, githubActivity GitHubActivity, filename string) error {
+// GenerateSimplifiedMarkdownSummary renders the summary data into filename.
+// templatePath selects a user template; the built-in one is used when it is empty.
+func GenerateSimplifiedMarkdownSummary(data SummaryData, templatePath string, filename string) error {
+	return GenerateSummary(data, FormatMarkdown, templatePath, filename)
+}
+
+// GenerateSummary writes the summary data into filename in the given format
+func GenerateSummary(data SummaryData, format string, templatePath string, filename string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("failed to create summary file: %w", err)
 	}
 	defer file.Close()
 
-	// Write header
-	today := time.Now().Format("Monday, January 2, 2006")
-	fmt.Fprintf(file, "# Daily Work Summary - %s\n\n", today)
-
-	// GitHub Activity Section
-	if githubActivity.TotalCommits > 0 || githubActivity.TotalPullRequests > 0 ||
-		githubActivity.TotalReviews > 0 || githubActivity.TotalIssues > 0 {
-		fmt.Fprintf(file, "## GitHub Activity\n\n")
-
-		// Pull Requests Created
-		if len(githubActivity.PullRequestsCreated) > 0 {
-			for _, pr := range githubActivity.PullRequestsCreated {
-				fmt.Fprintf(file, "- [%s/%s#%d: %s](%s)\n",
-					pr.RepoOwner, pr.RepoName, pr.Number, pr.Title, pr.URL)
-			}
-			fmt.Fprintln(file)
-		}
-
-		// Issues Created
-		if len(githubActivity.IssuesCreated) > 0 {
-			for _, issue := range githubActivity.IssuesCreated {
-				fmt.Fprintf(file, "- [%s/%s#%d: %s](%s)\n",
-					issue.RepoOwner, issue.RepoName, issue.Number, issue.Title, issue.URL)
-			}
-			fmt.Fprintln(file)
-		}
-
-		// Pull Request Reviews
-		if len(githubActivity.PullRequestsReviewed) > 0 {
-			for _, pr := range githubActivity.PullRequestsReviewed {
-				fmt.Fprintf(file, "- [%s/%s#%d: %s](%s) - Reviewed\n",
-					pr.RepoOwner, pr.RepoName, pr.Number, pr.Title, pr.URL)
-			}
-			fmt.Fprintln(file)
-		}
-
-		// Commits by Repository
-		if len(githubActivity.CommitsByRepo) > 0 {
-			for repo, count := range githubActivity.CommitsByRepo {
-				fmt.Fprintf(file, "- %s: %d commit(s)\n", repo, count)
-			}
-			fmt.Fprintln(file)
-		}
-	}
-
-	// Linear Issues Section
-	if len(issuesWithNotes) > 0 {
-		fmt.Fprintf(file, "## Linear Issues\n\n")
-
-		for _, issueNote := range issuesWithNotes {
-			issue := issueNote.Details
-
-			// First level: URL + short description
-			fmt.Fprintf(file, "- [%s: %s](%s)\n", issue.Identifier, issue.Title, issue.URL)
-
-			// Second level: user notes (if any)
-			if issueNote.UserNotes != "" {
-				noteLines := strings.Split(issueNote.UserNotes, "\n")
-				for _, line := range noteLines {
-					if strings.TrimSpace(line) != "" {
-						fmt.Fprintf(file, "  - %s\n", strings.TrimSpace(line))
-					}
-				}
-			}
-		}
-		fmt.Fprintln(file)
-	}
-
-	if len(issuesWithNotes) == 0 && githubActivity.TotalCommits == 0 &&
-		githubActivity.TotalPullRequests == 0 && githubActivity.TotalReviews == 0 &&
-		githubActivity.TotalIssues == 0 {
-		fmt.Fprintln(file, "No activity recorded for this period.")
-	}
-
-	return nil
+	return WriteFormatted(file, data, format, templatePath)
 }
@@ -0,0 +1,159 @@
+package daily
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/daily.md.tmpl
+var defaultSummaryTemplate string
+
+// SummaryData is the data model handed to summary templates.
+//
+// Templates can use:
+//   - .Date         time.Time the summary was generated at
+//   - .Window       the period covered, with .Window.Since and .Window.Until
+//   - .Issues       []IssueWithNotes, each with .Details (LinearIssueDetails), .UserNotes and
+//     .TimeSpent (0 unless asked for)
+//   - .GitHub       GitHubActivity: .Username, .TotalCommits, .TotalPullRequests, .TotalReviews,
+//     .TotalIssues, .CommitsByRepo (map of "owner/repo" to count), .PullRequestsCreated,
+//     .PullRequestsReviewed and .IssuesCreated
+//   - .Unavailable  []SourceStatus with .Source and .Reason for sources that failed
+//   - .NotWorked    []LinearIssueDetails for issues you said you did not work on
+//   - .Entries      []FreeFormEntry with .Text, .Category (may be empty) and .At for work
+//     not tied to an issue or PR, such as meetings, interviews or incident calls
+//   - .Sources      []SourceReport with .Name, .Status ("ok", "failed", "skipped"), .Error and .Items
+//   - .Plan         []PlanItem with .Identifier (may be empty), .Title and .URL planned for today
+//   - .Blockers     []Blocker with .Identifier, .Title and .URL of the blocked issue (may be
+//     empty) and the .Reason
+//   - .Days         the number of days a report covers, 0 for a daily summary
+//   - .ActiveTime   ActiveTime estimated from activity timestamps, with .Total, .Repos and
+//     .Issues ([]TimeEstimate with .Name and .Spent)
+//   - .Timeline     []TimelineEvent in chronological order, with .At (in the configured time
+//     zone), .Source, .Kind, .Label ("Opened", "Reviewed", "Moved"...), .Title, .Detail and
+//     .URL, only filled with "summary.timeline: true"
+//
+// as well as the helpers .HasGitHubActivity, .Empty, .MergedPullRequests and
+// .OtherPullRequests, and the template functions
+// noteLines (non-empty, trimmed lines of a note),
+// firstLine (the first of them), timeSpent (a duration as 1h30), join, lower, upper, trim
+// and indent.
+type SummaryData struct {
+	Date        time.Time
+	Window      Window
+	Issues      []IssueWithNotes
+	GitHub      GitHubActivity
+	Unavailable []SourceStatus
+	Sources     []SourceReport
+	NotWorked   []LinearIssueDetails
+	Entries     []FreeFormEntry
+	Plan        []PlanItem
+	Blockers    []Blocker
+	Days        int
+	ActiveTime  ActiveTime
+	Timeline    []TimelineEvent
+	// Fetched is set when a report's range was fetched again, so pull request states are
+	// the latest rather than those seen by each daily run
+	Fetched bool
+}
+
+// FreeFormEntry is work that is not tied to an issue or pull request
+type FreeFormEntry struct {
+	Text     string
+	Category string
+	At       time.Time
+}
+
+func (e FreeFormEntry) key() string {
+	return e.At.UTC().Format(time.RFC3339) + " " + e.Text
+}
+
+// HasGitHubActivity reports whether any GitHub contribution was found
+func (d SummaryData) HasGitHubActivity() bool {
+	return d.GitHub.TotalCommits > 0 || d.GitHub.TotalPullRequests > 0 ||
+		d.GitHub.TotalReviews > 0 || d.GitHub.TotalIssues > 0
+}
+
+// Empty reports whether there is nothing to summarise
+func (d SummaryData) Empty() bool {
+	return len(d.Issues) == 0 && len(d.Entries) == 0 && !d.HasGitHubActivity()
+}
+
+var templateFuncs = template.FuncMap{
+	"noteLines": noteLines,
+	"firstLine": firstLine,
+	"timeSpent": FormatTimeSpent,
+	"join":      strings.Join,
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trim":      strings.TrimSpace,
+	"indent": func(spaces int, text string) string {
+		padding := strings.Repeat(" ", spaces)
+		return padding + strings.ReplaceAll(text, "\n", "\n"+padding)
+	},
+}
+
+// LoadSummaryTemplate parses the template at path, or the built-in template when path is empty
+func LoadSummaryTemplate(path string) (*template.Template, error) {
+	return loadTemplate(path, "daily.md.tmpl", defaultSummaryTemplate)
+}
+
+// loadTemplate parses the template at path, or the built-in one when path is empty
+func loadTemplate(path string, name string, builtin string) (*template.Template, error) {
+	if path == "" {
+		return template.New(name).Funcs(templateFuncs).Parse(builtin)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read summary template: %w", err)
+	}
+
+	tmpl, err := template.New(filepath.Base(path)).Funcs(templateFuncs).Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse summary template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// RenderSummary executes the template at templatePath with data. Without a template path,
+// the built-in daily template is used, or the report template when data covers several days.
+func RenderSummary(w io.Writer, data SummaryData, templatePath string) error {
+	tmpl, err := LoadSummaryTemplate(templatePath)
+	if data.Days > 0 {
+		tmpl, err = loadTemplate(templatePath, "report.md.tmpl", defaultReportTemplate)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to render summary: %w", err)
+	}
+	return nil
+}
+
+// noteLines splits notes into their non-empty, trimmed lines
+func noteLines(notes string) []string {
+	var lines []string
+	for _, line := range strings.Split(notes, "\n") {
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return lines
+}
+
+// firstLine is the first non-empty, trimmed line of notes
+func firstLine(notes string) string {
+	if lines := noteLines(notes); len(lines) > 0 {
+		return lines[0]
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package daily
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sampleSummaryData() SummaryData {
+	return SummaryData{
+		Date: time.Date(2025, 10, 22, 18, 0, 0, 0, time.UTC),
+		Issues: []IssueWithNotes{
+			{
+				Details:   LinearIssueDetails{Identifier: "TEST-123", Title: "Implement auth", URL: "https://linear.app/test/issue/TEST-123"},
+				UserNotes: "Added token refresh\n\n  Wrote tests  ",
+			},
+		},
+		GitHub: GitHubActivity{
+			TotalCommits:         5,
+			TotalPullRequests:    1,
+			TotalReviews:         1,
+			CommitsByRepo:        map[string]int{"testorg/mastercrab": 5},
+			PullRequestsCreated:  []GitHubPullRequest{{Title: "feat: Add daily command", URL: "https://github.com/testorg/mastercrab/pull/10", Number: 10, RepoOwner: "testorg", RepoName: "mastercrab"}},
+			PullRequestsReviewed: []GitHubPullRequest{{Title: "fix: Handle empty responses", URL: "https://github.com/testorg/mastercrab/pull/5", Number: 5, RepoOwner: "testorg", RepoName: "mastercrab"}},
+		},
+	}
+}
+
+// Test the built-in template renders the standard summary layout
+func Test_RenderSummary_DefaultTemplate(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	err := RenderSummary(&out, sampleSummaryData(), "")
+
+	// Assert
+	require.NoError(t, err)
+	expected := `# Daily Work Summary - Wednesday, October 22, 2025
+
+## GitHub Activity
+
+- [testorg/mastercrab#10: feat: Add daily command](https://github.com/testorg/mastercrab/pull/10)
+
+- [testorg/mastercrab#5: fix: Handle empty responses](https://github.com/testorg/mastercrab/pull/5) - Reviewed
+
+- testorg/mastercrab: 5 commit(s)
+
+## Linear Issues
+
+- [TEST-123: Implement auth](https://linear.app/test/issue/TEST-123)
+  - Added token refresh
+  - Wrote tests
+
+`
+	assert.Equal(t, expected, out.String())
+}
+
+// Test the built-in template notes when there is no activity
+func Test_RenderSummary_Empty(t *testing.T) {
+	var out bytes.Buffer
+
+	err := RenderSummary(&out, SummaryData{Date: time.Date(2025, 10, 22, 0, 0, 0, 0, time.UTC)}, "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "# Daily Work Summary - Wednesday, October 22, 2025\n\nNo activity recorded for this period.\n", out.String())
+}
+
+// Test a user-provided template receives the data model
+func Test_RenderSummary_CustomTemplate(t *testing.T) {
+	// Arrange
+	templatePath := filepath.Join(t.TempDir(), "standup.tmpl")
+	custom := `{{ .Date.Format "2006-01-02" }} ({{ .GitHub.TotalCommits }} commits)
+{{ range .Issues }}* {{ .Details.Identifier }}: {{ join (noteLines .UserNotes) "; " }}
+{{ end }}`
+	require.NoError(t, os.WriteFile(templatePath, []byte(custom), 0o644))
+
+	// Act
+	var out bytes.Buffer
+	err := RenderSummary(&out, sampleSummaryData(), templatePath)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "2025-10-22 (5 commits)\n* TEST-123: Added token refresh; Wrote tests\n", out.String())
+}
+
+// Test an invalid user template reports its path
+func Test_RenderSummary_InvalidTemplate(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "broken.tmpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("{{ .Issues "), 0o644))
+
+	err := RenderSummary(&bytes.Buffer{}, sampleSummaryData(), templatePath)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broken.tmpl")
+}
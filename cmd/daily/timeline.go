@@ -0,0 +1,173 @@
+package daily
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Kinds of timeline events, GitHub items keep their ItemKind
+const (
+	TimelineCommit      = "commit"
+	TimelinePullRequest = ItemKindPullRequest
+	TimelineReview      = ItemKindReview
+	TimelineIssue       = ItemKindIssue
+	TimelineComment     = "comment"
+	TimelineStateChange = "state_change"
+)
+
+// TimelineEvent is something that happened at a known time during the day
+type TimelineEvent struct {
+	At time.Time
+	// Source is "github" or "linear"
+	Source string
+	Kind   string
+	// Title names the commit, pull request or issue, e.g. "acme/api#12: Add retries"
+	Title string
+	// Detail is the state change ("Todo → In Progress") or the comment, empty otherwise
+	Detail string
+	URL    string
+}
+
+// Label describes what happened, e.g. "Opened" or "Reviewed"
+func (e TimelineEvent) Label() string {
+	switch e.Kind {
+	case TimelineCommit:
+		return "Committed to"
+	case TimelinePullRequest:
+		return "Opened"
+	case TimelineReview:
+		return "Reviewed"
+	case TimelineIssue:
+		return "Opened issue"
+	case TimelineComment:
+		return "Comment on"
+	case TimelineStateChange:
+		return "Moved"
+	}
+	return e.Kind
+}
+
+func (e TimelineEvent) key() string {
+	return e.At.UTC().Format(time.RFC3339) + " " + e.Kind + " " + e.URL + " " + e.Title
+}
+
+// TimeZone is the time zone of the "timezone" config key, an IANA name such as
+// Europe/Paris. It is the local time zone when the key is not set.
+func TimeZone(config *viper.Viper) (*time.Location, error) {
+	name := config.GetString("timezone")
+	if name == "" {
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	return loc, nil
+}
+
+// BuildTimeline merges the commits, pull requests, reviews and issues of the GitHub
+// activity with the comments and state changes of the Linear issues into one timeline,
+// oldest first. Only what happened within the window is kept, with times in loc.
+func BuildTimeline(activity GitHubActivity, issues []LinearIssueDetails, window Window, loc *time.Location) []TimelineEvent {
+	if loc == nil {
+		loc = time.Local
+	}
+
+	var events []TimelineEvent
+	add := func(value string, event TimelineEvent) {
+		at, err := time.Parse(time.RFC3339, value)
+		if err != nil || at.Before(window.Since) || at.After(window.Until) {
+			return
+		}
+		event.At = at.In(loc)
+		events = append(events, event)
+	}
+
+	for _, commit := range activity.Commits {
+		add(commit.CommittedAt, TimelineEvent{Source: "github", Kind: TimelineCommit, Title: commit.Repo + ": " + commit.Headline, URL: commit.URL})
+	}
+	for _, pr := range activity.PullRequestsCreated {
+		add(pr.OccurredAt, TimelineEvent{Source: "github", Kind: TimelinePullRequest, Title: githubItemTitle(pr.RepoOwner, pr.RepoName, pr.Number, pr.Title), URL: pr.URL})
+	}
+	for _, pr := range activity.PullRequestsReviewed {
+		add(pr.OccurredAt, TimelineEvent{Source: "github", Kind: TimelineReview, Title: githubItemTitle(pr.RepoOwner, pr.RepoName, pr.Number, pr.Title), URL: pr.URL})
+	}
+	for _, issue := range activity.IssuesCreated {
+		add(issue.OccurredAt, TimelineEvent{Source: "github", Kind: TimelineIssue, Title: githubItemTitle(issue.RepoOwner, issue.RepoName, issue.Number, issue.Title), URL: issue.URL})
+	}
+
+	for _, issue := range issues {
+		title := issue.Identifier + ": " + issue.Title
+		for _, comment := range issue.Comments.Nodes {
+			detail := firstLine(comment.Body)
+			if comment.User.Name != "" {
+				detail = comment.User.Name + ": " + detail
+			}
+			add(comment.CreatedAt, TimelineEvent{Source: "linear", Kind: TimelineComment, Title: title, Detail: detail, URL: issue.URL})
+		}
+		for _, change := range issue.History.Nodes {
+			if change.ToState == nil {
+				continue
+			}
+			detail := change.ToState.Name
+			if change.FromState != nil {
+				detail = change.FromState.Name + " → " + detail
+			}
+			add(change.CreatedAt, TimelineEvent{Source: "linear", Kind: TimelineStateChange, Title: title, Detail: detail, URL: issue.URL})
+		}
+	}
+
+	sortTimeline(events)
+	return events
+}
+
+// sortTimeline puts the events in chronological order, keeping the order of simultaneous ones
+func sortTimeline(events []TimelineEvent) {
+	sort.SliceStable(events, func(i, j int) bool { return events[i].At.Before(events[j].At) })
+}
+
+func githubItemTitle(owner string, name string, number int, title string) string {
+	return fmt.Sprintf("%s/%s#%d: %s", owner, name, number, title)
+}
+
+// RenderTimeline writes the timeline for the terminal, one line per event with its time
+// in loc, under a heading for each day.
+func RenderTimeline(w io.Writer, events []TimelineEvent, loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	if len(events) == 0 {
+		fmt.Fprintln(w, "✅ Nothing happened at a known time in this period")
+		return
+	}
+
+	day := ""
+	for _, event := range events {
+		at := event.At.In(loc)
+		if key := DayKey(at); key != day {
+			day = key
+			fmt.Fprintf(w, "\n🕒 %s (%s)\n\n", at.Format("Monday, January 2"), loc)
+		}
+
+		fmt.Fprintf(w, "  %s  %s %s %s\n", at.Format("15:04"), timelineIcon(event.Kind), event.Label(), event.Title)
+		if event.Detail != "" {
+			fmt.Fprintf(w, "         %s\n", helpStyle.Render(event.Detail))
+		}
+	}
+}
+
+func timelineIcon(kind string) string {
+	switch kind {
+	case TimelineCommit:
+		return activityIcon(ItemKindCommits)
+	case TimelineComment:
+		return "💬"
+	case TimelineStateChange:
+		return "🔄"
+	}
+	return activityIcon(kind)
+}
@@ -0,0 +1,146 @@
+package daily
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func timelineIssue() LinearIssueDetails {
+	issue := LinearIssueDetails{Identifier: "ENG-1", Title: "Payment retries", URL: "https://linear.app/acme/issue/ENG-1"}
+	issue.Comments.Nodes = append(issue.Comments.Nodes, struct {
+		ID        string `json:"id"`
+		Body      string `json:"body"`
+		CreatedAt string `json:"createdAt"`
+		UpdatedAt string `json:"updatedAt"`
+		User      struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}{Body: "Retries are live\nMore below", CreatedAt: "2026-10-16T13:15:00Z"})
+	issue.Comments.Nodes[0].User.Name = "Ann"
+
+	change := LinearIssueHistory{CreatedAt: "2026-10-16T08:30:00Z"}
+	change.FromState = &struct {
+		Name string `json:"name"`
+	}{Name: "Todo"}
+	change.ToState = &struct {
+		Name string `json:"name"`
+	}{Name: "In Progress"}
+	issue.History.Nodes = []LinearIssueHistory{
+		change,
+		// Not a state change
+		{CreatedAt: "2026-10-16T09:00:00Z"},
+		// Before the window
+		{CreatedAt: "2026-10-14T09:00:00Z", ToState: change.ToState},
+	}
+	return issue
+}
+
+// Test GitHub activity and Linear comments and state changes are merged in order, in the time zone
+func Test_BuildTimeline(t *testing.T) {
+	// Arrange
+	paris, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	activity := GitHubActivity{
+		Commits:              []GitHubCommit{{Repo: "acme/api", Headline: "Add retries", URL: "https://github.com/acme/api/commit/1", CommittedAt: "2026-10-16T10:05:00Z"}},
+		PullRequestsCreated:  []GitHubPullRequest{{Title: "Retries", URL: "https://github.com/acme/api/pull/12", Number: 12, RepoOwner: "acme", RepoName: "api", OccurredAt: "2026-10-16T11:20:00Z"}},
+		PullRequestsReviewed: []GitHubPullRequest{{Title: "Bump deps", URL: "https://github.com/acme/web/pull/3", Number: 3, RepoOwner: "acme", RepoName: "web", OccurredAt: "2026-10-16T09:40:00Z"}},
+		IssuesCreated:        []GitHubIssue{{Title: "Flaky test", URL: "https://github.com/acme/api/issues/13", Number: 13, RepoOwner: "acme", RepoName: "api", OccurredAt: "not a time"}},
+	}
+	window := Window{Since: at("00:00"), Until: at("23:59")}
+
+	// Act
+	events := BuildTimeline(activity, []LinearIssueDetails{timelineIssue()}, window, paris)
+
+	// Assert
+	require.Len(t, events, 5)
+	assert.Equal(t, []string{TimelineStateChange, TimelineReview, TimelineCommit, TimelinePullRequest, TimelineComment},
+		[]string{events[0].Kind, events[1].Kind, events[2].Kind, events[3].Kind, events[4].Kind})
+	assert.Equal(t, "10:30", events[0].At.Format("15:04"))
+	assert.Equal(t, paris, events[0].At.Location())
+	assert.Equal(t, TimelineEvent{
+		At: at("08:30").In(paris), Source: "linear", Kind: TimelineStateChange,
+		Title: "ENG-1: Payment retries", Detail: "Todo → In Progress", URL: "https://linear.app/acme/issue/ENG-1",
+	}, events[0])
+	assert.Equal(t, "acme/web#3: Bump deps", events[1].Title)
+	assert.Equal(t, "acme/api: Add retries", events[2].Title)
+	assert.Equal(t, "Ann: Retries are live", events[4].Detail)
+}
+
+// Test the timeline is printed with local times under a heading for the day
+func Test_RenderTimeline(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	utc := time.UTC
+	events := BuildTimeline(GitHubActivity{
+		Commits: []GitHubCommit{{Repo: "acme/api", Headline: "Add retries", CommittedAt: "2026-10-16T10:05:00Z"}},
+	}, []LinearIssueDetails{timelineIssue()}, Window{Since: at("00:00"), Until: at("23:59")}, utc)
+
+	// Act
+	RenderTimeline(&out, events, utc)
+
+	// Assert
+	assert.Contains(t, out.String(), "🕒 Friday, October 16 (UTC)")
+	assert.Contains(t, out.String(), "  08:30  🔄 Moved ENG-1: Payment retries\n")
+	assert.Contains(t, out.String(), "  10:05  💾 Committed to acme/api: Add retries\n")
+	assert.Contains(t, out.String(), "Todo → In Progress")
+	assert.Less(t, bytes.Index(out.Bytes(), []byte("08:30")), bytes.Index(out.Bytes(), []byte("13:15")))
+}
+
+// Test the time zone comes from config and defaults to local time
+func Test_TimeZone(t *testing.T) {
+	// Arrange
+	config := viper.New()
+
+	// Act
+	local, localErr := TimeZone(config)
+	config.Set("timezone", "America/New_York")
+	newYork, newYorkErr := TimeZone(config)
+	config.Set("timezone", "Mars/Olympus")
+	_, invalidErr := TimeZone(config)
+
+	// Assert
+	require.NoError(t, localErr)
+	require.NoError(t, newYorkErr)
+	assert.Equal(t, time.Local, local)
+	assert.Equal(t, "America/New_York", newYork.String())
+	assert.ErrorContains(t, invalidErr, "Mars/Olympus")
+}
+
+// Test the built-in template lists the timeline when it is filled
+func Test_RenderSummary_Timeline(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+	data := sampleSummaryData()
+	data.Timeline = BuildTimeline(GitHubActivity{}, []LinearIssueDetails{timelineIssue()}, Window{Since: at("00:00"), Until: at("23:59")}, time.UTC)
+
+	// Act
+	err := RenderSummary(&out, data, "")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `## Timeline
+
+- **08:30** Moved [ENG-1: Payment retries](https://linear.app/acme/issue/ENG-1): Todo → In Progress
+- **13:15** Comment on [ENG-1: Payment retries](https://linear.app/acme/issue/ENG-1): Ann: Retries are live
+`)
+}
+
+// Test runs of the same day keep every timeline event once, in order
+func Test_MergeExports_Timeline(t *testing.T) {
+	// Arrange
+	events := BuildTimeline(GitHubActivity{}, []LinearIssueDetails{timelineIssue()}, Window{Since: at("00:00"), Until: at("23:59")}, time.UTC)
+	previous := NewDailyExport(SummaryData{Timeline: events[1:]})
+	current := NewDailyExport(SummaryData{Timeline: events})
+
+	// Act
+	merged := MergeExports(previous, current)
+
+	// Assert
+	assert.Equal(t, events, merged.SummaryData().Timeline)
+	assert.Nil(t, MergeExports(NewDailyExport(SummaryData{}), NewDailyExport(SummaryData{})).Timeline)
+}
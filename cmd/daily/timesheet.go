@@ -0,0 +1,281 @@
+package daily
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timesheet formats supported by "timesheet"
+const (
+	TimesheetCSV      = "csv"
+	TimesheetMarkdown = "markdown"
+)
+
+// Timesheet groupings supported by "timesheet"
+const (
+	TimesheetByDay     = "day"
+	TimesheetByIssue   = "issue"
+	TimesheetByProject = "project"
+)
+
+// timeSpentPattern matches "1h30", "1h30m", "2h", "45m" and "1.5h"
+var timeSpentPattern = regexp.MustCompile(`^(?:(\d+(?:\.\d+)?)h)?(?:(\d+)m?)?$`)
+
+// clockPattern matches "1:30"
+var clockPattern = regexp.MustCompile(`^(\d+):(\d{1,2})$`)
+
+// bareHoursPattern matches a number without a unit, such as "2" or "1.5"
+var bareHoursPattern = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+
+// maxBareHours is the largest number without a unit read as hours. Larger ones are more
+// likely minutes typed without the "m", such as 45.
+const maxBareHours = 12
+
+// ParseTimeSpent reads a time spent such as 1h30, 1h30m, 2h, 45m, 1.5h or 1:30.
+// A bare number up to maxBareHours is a number of hours, larger ones need a unit.
+// Minutes after hours stay below 60, and the time spent is always positive.
+func ParseTimeSpent(value string) (time.Duration, error) {
+	value = strings.ToLower(strings.ReplaceAll(value, " ", ""))
+	if value == "" {
+		return 0, fmt.Errorf("no time given")
+	}
+	invalid := fmt.Errorf("invalid time %q (expected e.g. 1h30, 45m or 1:30)", value)
+
+	var spent time.Duration
+	switch {
+	case clockPattern.MatchString(value):
+		match := clockPattern.FindStringSubmatch(value)
+		h, _ := strconv.Atoi(match[1])
+		m, _ := strconv.Atoi(match[2])
+		if m >= 60 {
+			return 0, invalid
+		}
+		spent = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+	case bareHoursPattern.MatchString(value):
+		hours, _ := strconv.ParseFloat(value, 64)
+		if hours > maxBareHours {
+			return 0, fmt.Errorf("%q has no unit, did you mean %sm or %sh?", value, value, value)
+		}
+		spent = time.Duration(hours * float64(time.Hour))
+	default:
+		match := timeSpentPattern.FindStringSubmatch(value)
+		if match == nil || (match[1] == "" && !strings.HasSuffix(value, "m")) {
+			return 0, invalid
+		}
+		if match[1] != "" {
+			hours, _ := strconv.ParseFloat(match[1], 64)
+			spent += time.Duration(hours * float64(time.Hour))
+		}
+		if match[2] != "" {
+			minutes, _ := strconv.Atoi(match[2])
+			if match[1] != "" && minutes >= 60 {
+				return 0, invalid
+			}
+			spent += time.Duration(minutes) * time.Minute
+		}
+	}
+
+	spent = roundMinutes(spent)
+	if spent <= 0 {
+		return 0, fmt.Errorf("time %q is not positive, leave it empty or use - for none", value)
+	}
+	return spent, nil
+}
+
+// FormatTimeSpent writes a time spent the way it is typed, e.g. 1h30, 2h or 45m
+func FormatTimeSpent(spent time.Duration) string {
+	spent = roundMinutes(spent)
+	hours, minutes := int(spent/time.Hour), int(spent%time.Hour/time.Minute)
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%02d", hours, minutes)
+}
+
+func roundMinutes(spent time.Duration) time.Duration {
+	return spent.Round(time.Minute)
+}
+
+// PromptForTimeSpent asks how long was spent on an issue, offering a default. An empty
+// answer keeps the default, "0" or "-" records no time, and invalid answers are asked again.
+func PromptForTimeSpent(input io.Reader, defaultSpent time.Duration) (time.Duration, error) {
+	reader := bufio.NewReader(input)
+	for {
+		if defaultSpent > 0 {
+			fmt.Fprintf(console, "\n⏱️  Time spent? (e.g. 1h30, 45m, Enter for %s, - for none)\n", FormatTimeSpent(defaultSpent))
+		} else {
+			fmt.Fprintln(console, "\n⏱️  Time spent? (e.g. 1h30, 45m, Enter for none)")
+		}
+
+		response, err := readPromptLine(reader)
+		if err != nil && response == "" {
+			return defaultSpent, err
+		}
+		switch response {
+		case "":
+			return defaultSpent, nil
+		case "-", "0":
+			return 0, nil
+		}
+
+		spent, parseErr := ParseTimeSpent(response)
+		if parseErr == nil {
+			return spent, nil
+		}
+		fmt.Fprintf(console, "⚠️  %s\n", parseErr)
+		if err != nil {
+			return defaultSpent, err
+		}
+	}
+}
+
+// TimesheetRow is the time spent on an issue, or on a project, for a day or a range
+type TimesheetRow struct {
+	// Date is zero when the row covers the whole range
+	Date       time.Time
+	Identifier string
+	Title      string
+	URL        string
+	Project    string
+	Spent      time.Duration
+}
+
+// Hours is the time spent as a decimal number of hours
+func (r TimesheetRow) Hours() float64 {
+	return math.Round(r.Spent.Hours()*100) / 100
+}
+
+// ParseTimesheetGrouping validates a timesheet grouping
+func ParseTimesheetGrouping(by string) (string, error) {
+	switch strings.ToLower(by) {
+	case "", TimesheetByDay:
+		return TimesheetByDay, nil
+	case TimesheetByIssue:
+		return TimesheetByIssue, nil
+	case TimesheetByProject:
+		return TimesheetByProject, nil
+	}
+	return "", fmt.Errorf("unknown grouping %q (expected day, issue or project)", by)
+}
+
+// ParseTimesheetFormat validates a timesheet format
+func ParseTimesheetFormat(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case "", TimesheetCSV:
+		return TimesheetCSV, nil
+	case "md", TimesheetMarkdown:
+		return TimesheetMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown format %q (expected csv or markdown)", format)
+}
+
+// BuildTimesheet adds up the time recorded for Linear issues in the days. With
+// TimesheetByDay there is a row per day and issue, with TimesheetByIssue a row per issue
+// and with TimesheetByProject a row per project. Issues without time are left out.
+func BuildTimesheet(days []DailyExport, by string) []TimesheetRow {
+	var rows []TimesheetRow
+	index := make(map[string]int)
+	for _, day := range days {
+		date := time.Date(day.GeneratedAt.Year(), day.GeneratedAt.Month(), day.GeneratedAt.Day(), 0, 0, 0, 0, day.GeneratedAt.Location())
+		for _, issue := range day.Issues {
+			if issue.Minutes <= 0 {
+				continue
+			}
+
+			row := TimesheetRow{
+				Date:       date,
+				Identifier: issue.Identifier,
+				Title:      issue.Title,
+				URL:        issue.URL,
+				Project:    issue.Project,
+				Spent:      time.Duration(issue.Minutes) * time.Minute,
+			}
+			key := DayKey(date) + " " + issue.Identifier
+			switch by {
+			case TimesheetByIssue:
+				row.Date = time.Time{}
+				key = issue.Identifier
+			case TimesheetByProject:
+				row = TimesheetRow{Project: issue.Project, Spent: row.Spent}
+				key = issue.Project
+			}
+
+			if i, ok := index[key]; ok {
+				rows[i].Spent += row.Spent
+				continue
+			}
+			index[key] = len(rows)
+			rows = append(rows, row)
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		if !rows[i].Date.Equal(rows[j].Date) {
+			return rows[i].Date.Before(rows[j].Date)
+		}
+		if rows[i].Project != rows[j].Project {
+			return rows[i].Project < rows[j].Project
+		}
+		return rows[i].Identifier < rows[j].Identifier
+	})
+	return rows
+}
+
+// WriteTimesheet writes the rows of a timesheet as CSV or a markdown table, with a total
+func WriteTimesheet(w io.Writer, rows []TimesheetRow, by string, format string) error {
+	header := []string{"date", "identifier", "title", "project", "hours", "url"}
+	switch by {
+	case TimesheetByIssue:
+		header = []string{"identifier", "title", "project", "hours", "url"}
+	case TimesheetByProject:
+		header = []string{"project", "hours"}
+	}
+
+	var total time.Duration
+	records := [][]string{}
+	for _, row := range rows {
+		total += row.Spent
+		hours := strconv.FormatFloat(row.Hours(), 'f', 2, 64)
+		switch by {
+		case TimesheetByIssue:
+			records = append(records, []string{row.Identifier, row.Title, row.Project, hours, row.URL})
+		case TimesheetByProject:
+			records = append(records, []string{row.Project, hours})
+		default:
+			records = append(records, []string{DayKey(row.Date), row.Identifier, row.Title, row.Project, hours, row.URL})
+		}
+	}
+
+	if format == TimesheetCSV {
+		writer := csv.NewWriter(w)
+		if err := writer.Write(header); err != nil {
+			return fmt.Errorf("failed to write timesheet: %w", err)
+		}
+		if err := writer.WriteAll(records); err != nil {
+			return fmt.Errorf("failed to write timesheet: %w", err)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(w, "| %s |\n", strings.Join(header, " | "))
+	fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(header)))
+	for _, record := range records {
+		for i := range record {
+			record[i] = strings.ReplaceAll(record[i], "|", "\\|")
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(record, " | "))
+	}
+	_, err := fmt.Fprintf(w, "\n**Total:** %s hours\n", strconv.FormatFloat(math.Round(total.Hours()*100)/100, 'f', 2, 64))
+	return err
+}
@@ -0,0 +1,138 @@
+package daily
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Test the time spent shortcuts
+func Test_ParseTimeSpent(t *testing.T) {
+	cases := map[string]time.Duration{
+		"1h30":   90 * time.Minute,
+		"1h30m":  90 * time.Minute,
+		"2h":     2 * time.Hour,
+		"45m":    45 * time.Minute,
+		"1.5h":   90 * time.Minute,
+		"1:30":   90 * time.Minute,
+		"2":      2 * time.Hour,
+		" 1H 5m": 65 * time.Minute,
+	}
+	for value, expected := range cases {
+		// Act
+		spent, err := ParseTimeSpent(value)
+
+		// Assert
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, spent, value)
+	}
+
+	for _, value := range []string{
+		"", "h", "m", "abc", "1:75", "1h30x", "45", "12.5",
+		"-1", "-0.5", "-1h", "-1:30", "nan", "inf", "1e1", "1e1h",
+		"1h75", "1h60m", "0", "0h", "0m", "0:00",
+	} {
+		_, err := ParseTimeSpent(value)
+		assert.Error(t, err, value)
+	}
+}
+
+// Test durations are written the way they are typed
+func Test_FormatTimeSpent(t *testing.T) {
+	assert.Equal(t, "1h30", FormatTimeSpent(90*time.Minute))
+	assert.Equal(t, "2h", FormatTimeSpent(2*time.Hour))
+	assert.Equal(t, "45m", FormatTimeSpent(45*time.Minute))
+	assert.Equal(t, "1h05", FormatTimeSpent(65*time.Minute))
+}
+
+// Test an empty answer keeps the default and invalid answers are asked again
+func Test_PromptForTimeSpent(t *testing.T) {
+	// Act
+	kept, err := PromptForTimeSpent(strings.NewReader("\n"), time.Hour)
+	require.NoError(t, err)
+	retried, err := PromptForTimeSpent(strings.NewReader("soon\n45m\n"), time.Hour)
+	require.NoError(t, err)
+	none, err := PromptForTimeSpent(strings.NewReader("-\n"), time.Hour)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, time.Hour, kept)
+	assert.Equal(t, 45*time.Minute, retried)
+	assert.Zero(t, none)
+}
+
+func timesheetDays() []DailyExport {
+	monday := time.Date(2026, 10, 12, 18, 0, 0, 0, time.UTC)
+	return []DailyExport{
+		{GeneratedAt: monday, Issues: []ExportIssue{
+			{Identifier: "ENG-1", Title: "Retries", Project: "Payments", Minutes: 90},
+			{Identifier: "ENG-2", Title: "Docs", Project: "Platform", Minutes: 30},
+			{Identifier: "ENG-3", Title: "No time"},
+		}},
+		{GeneratedAt: monday.AddDate(0, 0, 1), Issues: []ExportIssue{
+			{Identifier: "ENG-1", Title: "Retries", Project: "Payments", Minutes: 60},
+		}},
+	}
+}
+
+// Test time is added up per day, issue or project
+func Test_BuildTimesheet(t *testing.T) {
+	// Act
+	byDay := BuildTimesheet(timesheetDays(), TimesheetByDay)
+	byIssue := BuildTimesheet(timesheetDays(), TimesheetByIssue)
+	byProject := BuildTimesheet(timesheetDays(), TimesheetByProject)
+
+	// Assert
+	require.Len(t, byDay, 3)
+	assert.Equal(t, "ENG-1", byDay[0].Identifier)
+	assert.Equal(t, "2026-10-12", DayKey(byDay[0].Date))
+	assert.Equal(t, "ENG-2", byDay[1].Identifier)
+	assert.Equal(t, "ENG-1", byDay[2].Identifier)
+	assert.Equal(t, "2026-10-13", DayKey(byDay[2].Date))
+
+	require.Len(t, byIssue, 2)
+	assert.Equal(t, "ENG-1", byIssue[0].Identifier)
+	assert.Equal(t, 2.5, byIssue[0].Hours())
+
+	require.Len(t, byProject, 2)
+	assert.Equal(t, TimesheetRow{Project: "Payments", Spent: 150 * time.Minute}, byProject[0])
+	assert.Equal(t, TimesheetRow{Project: "Platform", Spent: 30 * time.Minute}, byProject[1])
+}
+
+// Test the timesheet is written as CSV and as a markdown table
+func Test_WriteTimesheet(t *testing.T) {
+	// Arrange
+	rows := BuildTimesheet(timesheetDays(), TimesheetByIssue)
+	var csvOut, markdownOut bytes.Buffer
+
+	// Act
+	require.NoError(t, WriteTimesheet(&csvOut, rows, TimesheetByIssue, TimesheetCSV))
+	require.NoError(t, WriteTimesheet(&markdownOut, rows, TimesheetByIssue, TimesheetMarkdown))
+
+	// Assert
+	assert.Equal(t, "identifier,title,project,hours,url\nENG-1,Retries,Payments,2.50,\nENG-2,Docs,Platform,0.50,\n", csvOut.String())
+	assert.Contains(t, markdownOut.String(), "| ENG-1 | Retries | Payments | 2.50 |  |")
+	assert.Contains(t, markdownOut.String(), "**Total:** 3.00 hours")
+}
+
+// Test the time spent survives the export and a later run without time
+func Test_TimeSpent_Export(t *testing.T) {
+	// Arrange
+	data := SummaryData{Issues: []IssueWithNotes{{Details: LinearIssueDetails{ID: "1", Identifier: "ENG-1"}, TimeSpent: 90 * time.Minute}}}
+	data.Issues[0].Details.Project.Name = "Payments"
+	later := NewDailyExport(SummaryData{Issues: []IssueWithNotes{{Details: LinearIssueDetails{ID: "1", Identifier: "ENG-1"}, UserNotes: "More"}}})
+
+	// Act
+	export := NewDailyExport(data)
+	merged := MergeExports(export, later)
+
+	// Assert
+	assert.Equal(t, 90, export.Issues[0].Minutes)
+	assert.Equal(t, "Payments", export.Issues[0].Project)
+	assert.Equal(t, 90*time.Minute, export.SummaryData().Issues[0].TimeSpent)
+	assert.Equal(t, 90, merged.Issues[0].Minutes)
+}
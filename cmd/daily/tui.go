@@ -0,0 +1,744 @@
+package daily
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Answers given for an item in the review TUI
+const (
+	DecisionPending   = ""
+	DecisionWorked    = "worked"
+	DecisionNotWorked = "not_worked"
+	DecisionSkipped   = "skipped"
+)
+
+// ReviewEntry is one item listed in the review TUI
+type ReviewEntry struct {
+	Item ActivityItem
+	// Details and Err are only set for Linear issues, other items are always loaded
+	Details  LinearIssueDetails
+	Loaded   bool
+	Err      error
+	Decision string
+	Notes    string
+	// TimeSpent is only asked for Linear issues worked on
+	TimeSpent time.Duration
+}
+
+// ReviewOptions is what the review TUI works through
+type ReviewOptions struct {
+	// Issues are the Linear issues to answer. Their details arrive on Details, in any order.
+	Issues  []ActivityItem
+	Details <-chan PrefetchedIssue
+	// Activity is the GitHub activity. Its pull requests, reviews and issues can be annotated
+	// or left out; commits are listed for context only.
+	Activity []ActivityItem
+	// PreviousNotes are notes from an earlier run, offered when editing an issue's notes
+	PreviousNotes map[string]string
+	// Journal notes are offered with the earlier notes of their issue
+	Journal Journal
+	// Answers are applied to their issues as soon as the details arrive
+	Answers   Answers
+	UseEditor bool
+	// AskTime asks for the time spent on each issue worked on, offering TimeDefault
+	AskTime     bool
+	TimeDefault func(details LinearIssueDetails) time.Duration
+	// Session receives every answer as soon as it is given
+	Session *Session
+}
+
+// RunReviewTUI shows the day's activity full screen until the user is done and returns
+// the answers given. It also returns when ctx is cancelled, with the answers so far.
+// interrupted reports whether the review ended before the user finished it.
+func RunReviewTUI(ctx context.Context, options ReviewOptions) (entries []ReviewEntry, interrupted bool, err error) {
+	// Pick the markdown style now: the terminal cannot be queried once the TUI owns it
+	style := glamour.WithStandardStyle(styles.LightStyle)
+	if lipgloss.HasDarkBackground() {
+		style = glamour.WithStandardStyle(styles.DarkStyle)
+	}
+
+	model := newReviewModel(options, style)
+	program := tea.NewProgram(model,
+		tea.WithAltScreen(),
+		tea.WithContext(ctx),
+		tea.WithInput(os.Stdin),
+		tea.WithOutput(console),
+	)
+
+	final, err := program.Run()
+	if final == nil {
+		return model.entries, true, err
+	}
+	result := final.(reviewModel)
+	if errors.Is(err, tea.ErrProgramKilled) {
+		return result.entries, true, nil
+	}
+	return result.entries, result.interrupted, err
+}
+
+// ReviewResults splits the answers from the review TUI like the line-by-line prompts do.
+// Issues whose details could not be fetched are reported as unavailable.
+func ReviewResults(entries []ReviewEntry) (worked []IssueWithNotes, notWorked []LinearIssueDetails, unavailable []SourceStatus) {
+	for _, entry := range entries {
+		if entry.Item.Kind != ItemKindLinearIssue {
+			continue
+		}
+
+		if entry.Err != nil {
+			unavailable = append(unavailable, SourceStatus{
+				Source: fmt.Sprintf("linear (%s)", entry.Item.Title),
+				Reason: entry.Err.Error(),
+			})
+			continue
+		}
+
+		switch entry.Decision {
+		case DecisionWorked:
+			worked = append(worked, IssueWithNotes{Details: entry.Details, UserNotes: entry.Notes, TimeSpent: entry.TimeSpent})
+		case DecisionNotWorked:
+			notWorked = append(notWorked, entry.Details)
+		}
+	}
+	return worked, notWorked, unavailable
+}
+
+// issueDetailsMsg delivers the details of one issue
+type issueDetailsMsg PrefetchedIssue
+
+// editorNotesMsg delivers the notes written in the external editor
+type editorNotesMsg struct {
+	entry int
+	notes string
+	err   error
+}
+
+type reviewModel struct {
+	entries       []ReviewEntry
+	issueIndex    map[string]int
+	details       <-chan PrefetchedIssue
+	previousNotes map[string]string
+	journal       Journal
+	answers       Answers
+	useEditor     bool
+	askTime       bool
+	timeDefault   func(details LinearIssueDetails) time.Duration
+	session       *Session
+	style         glamour.TermRendererOption
+
+	cursor   int
+	viewport viewport.Model
+	notes    textarea.Model
+	editing  bool
+	status   string
+	width    int
+	height   int
+	// rendered caches the detail view of each entry for the current width
+	rendered map[int]string
+	// timeInput takes the time spent on the issue under the cursor while editingTime
+	timeInput   textinput.Model
+	editingTime bool
+
+	interrupted bool
+}
+
+func newReviewModel(options ReviewOptions, style glamour.TermRendererOption) reviewModel {
+	model := reviewModel{
+		issueIndex:    make(map[string]int),
+		details:       options.Details,
+		previousNotes: options.PreviousNotes,
+		journal:       options.Journal,
+		answers:       options.Answers,
+		useEditor:     options.UseEditor,
+		askTime:       options.AskTime,
+		timeDefault:   options.TimeDefault,
+		session:       options.Session,
+		style:         style,
+		viewport:      viewport.New(80, 20),
+		notes:         textarea.New(),
+		timeInput:     textinput.New(),
+		width:         120,
+		height:        30,
+		rendered:      make(map[int]string),
+	}
+	model.notes.ShowLineNumbers = false
+	model.timeInput.Placeholder = "e.g. 1h30, 45m or 1:30, - for none"
+
+	for _, issue := range options.Issues {
+		model.issueIndex[issue.ID] = len(model.entries)
+		model.entries = append(model.entries, ReviewEntry{Item: issue})
+	}
+	for _, item := range options.Activity {
+		model.entries = append(model.entries, ReviewEntry{Item: item, Loaded: true})
+	}
+
+	model.layout()
+	return model
+}
+
+func (m reviewModel) Init() tea.Cmd {
+	return waitForDetails(m.details)
+}
+
+// waitForDetails delivers the next issue's details to the TUI
+func waitForDetails(details <-chan PrefetchedIssue) tea.Cmd {
+	if details == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		fetched, ok := <-details
+		if !ok {
+			return nil
+		}
+		return issueDetailsMsg(fetched)
+	}
+}
+
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.rendered = make(map[int]string)
+		m.layout()
+		return m, nil
+
+	case issueDetailsMsg:
+		if i, ok := m.issueIndex[msg.Item.ID]; ok {
+			m.entries[i].Details = msg.Details
+			m.entries[i].Err = msg.Err
+			m.entries[i].Loaded = true
+			delete(m.rendered, i)
+			if i == m.cursor {
+				m.showCurrent()
+			}
+
+			answer, ok := m.answers.Lookup(msg.Details.Identifier)
+			if ok && msg.Err == nil && m.entries[i].Decision == DecisionPending {
+				decision := DecisionNotWorked
+				if answer.Worked {
+					decision = DecisionWorked
+				}
+				m.answer(i, decision, answer.Notes)
+				if answer.Worked && answer.TimeSpent > 0 {
+					m.recordTime(i, answer.TimeSpent)
+				}
+			}
+		}
+		return m, waitForDetails(m.details)
+
+	case editorNotesMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("⚠️  Could not use your editor (%s), write the notes here instead", msg.err)
+			return m, m.startEditing(msg.entry)
+		}
+		m.answer(msg.entry, DecisionWorked, msg.notes)
+		return m, m.askTimeSpent(msg.entry)
+
+	case tea.KeyMsg:
+		if m.editingTime {
+			return m.updateEditingTime(msg)
+		}
+		if m.editing {
+			return m.updateEditing(msg)
+		}
+		return m.updateBrowsing(msg)
+	}
+
+	return m, nil
+}
+
+func (m reviewModel) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.status = ""
+
+	switch msg.String() {
+	case "ctrl+c":
+		m.interrupted = true
+		return m, tea.Quit
+	case "q":
+		return m, tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "home", "g":
+		m.move(-len(m.entries))
+	case "end", "G":
+		m.move(len(m.entries))
+	case "y", "w", "e":
+		if m.answerable() {
+			return m, m.editNotes(m.cursor)
+		}
+	case "n":
+		if m.answerable() {
+			m.answer(m.cursor, DecisionNotWorked, "")
+			m.move(1)
+		}
+	case "s":
+		if m.answerable() {
+			m.answer(m.cursor, DecisionSkipped, "")
+			m.move(1)
+		}
+	case "t":
+		switch {
+		case !m.askTime:
+			m.status = "Time spent is asked with --time or notes.time: true"
+		case m.entries[m.cursor].Item.Kind != ItemKindLinearIssue:
+			m.status = "Time spent is only recorded for Linear issues"
+		case m.answerable() && m.entries[m.cursor].Decision != DecisionWorked:
+			m.status = "Mark the issue as worked on (y) first"
+		case m.answerable():
+			return m, m.startEditingTime(m.cursor)
+		}
+	default:
+		// Anything else scrolls the details
+		var cmd tea.Cmd
+		m.viewport, cmd = m.viewport.Update(msg)
+		return m, cmd
+	}
+
+	return m, nil
+}
+
+func (m reviewModel) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+s":
+		m.editing = false
+		m.notes.Blur()
+		m.answer(m.cursor, DecisionWorked, strings.TrimSpace(m.notes.Value()))
+		return m, m.askTimeSpent(m.cursor)
+	case "esc":
+		m.editing = false
+		m.notes.Blur()
+		m.status = "Notes discarded"
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.notes, cmd = m.notes.Update(msg)
+	return m, cmd
+}
+
+func (m reviewModel) updateEditingTime(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		var spent time.Duration
+		switch value := strings.TrimSpace(m.timeInput.Value()); value {
+		case "", "-", "0":
+		default:
+			var err error
+			spent, err = ParseTimeSpent(value)
+			if err != nil {
+				m.status = fmt.Sprintf("⚠️  %s", err)
+				return m, nil
+			}
+		}
+		m.editingTime = false
+		m.timeInput.Blur()
+		m.status = ""
+		m.recordTime(m.cursor, spent)
+		return m, nil
+	case "esc":
+		m.editingTime = false
+		m.timeInput.Blur()
+		m.status = "Time spent left as it was"
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.timeInput, cmd = m.timeInput.Update(msg)
+	return m, cmd
+}
+
+// answerable reports whether the entry under the cursor takes an answer, explaining why not in the status line
+func (m *reviewModel) answerable() bool {
+	entry := m.entries[m.cursor]
+	switch {
+	case entry.Item.Kind == ItemKindCommits:
+		m.status = "Commits are always part of the summary"
+	case !entry.Loaded:
+		m.status = "⏳ Details are still loading"
+	case entry.Err != nil:
+		m.status = "⚠️  Details could not be fetched, the issue is listed as unavailable"
+	default:
+		return true
+	}
+	return false
+}
+
+// editNotes opens the notes of an entry in $EDITOR or in the built-in editor
+func (m *reviewModel) editNotes(i int) tea.Cmd {
+	entry := m.entries[i]
+	notes := entry.Notes
+	if notes == "" {
+		notes = m.defaultNotes(entry)
+	}
+
+	if m.useEditor {
+		subject := issueEditorSubject(entry.Details)
+		if entry.Item.Kind != ItemKindLinearIssue {
+			subject = itemEditorSubject(entry.Item)
+		}
+		command, path, err := notesEditorCommand(subject, notes)
+		if err == nil {
+			return tea.ExecProcess(command, func(err error) tea.Msg {
+				defer os.Remove(path)
+				if err != nil {
+					return editorNotesMsg{entry: i, err: err}
+				}
+				notes, err := readEditedNotes(path)
+				return editorNotesMsg{entry: i, notes: notes, err: err}
+			})
+		}
+		m.status = fmt.Sprintf("⚠️  Could not use your editor (%s), write the notes here instead", err)
+	}
+
+	return m.startEditing(i)
+}
+
+// defaultNotes are the notes offered for an entry before it is answered
+func (m reviewModel) defaultNotes(entry ReviewEntry) string {
+	return MergeNotes(m.previousNotes[entry.Item.ID], m.journal.NotesFor(entry.Details.Identifier))
+}
+
+func (m *reviewModel) startEditing(i int) tea.Cmd {
+	entry := m.entries[i]
+	notes := entry.Notes
+	if notes == "" {
+		notes = m.defaultNotes(entry)
+	}
+
+	m.cursor = i
+	m.editing = true
+	m.notes.Placeholder = notesPlaceholder(entry.Item.Kind)
+	m.notes.SetValue(notes)
+	return m.notes.Focus()
+}
+
+// askTimeSpent asks for the time spent on an issue just marked as worked on, when time is asked
+func (m *reviewModel) askTimeSpent(i int) tea.Cmd {
+	if !m.askTime || m.entries[i].Item.Kind != ItemKindLinearIssue {
+		return nil
+	}
+	return m.startEditingTime(i)
+}
+
+func (m *reviewModel) startEditingTime(i int) tea.Cmd {
+	entry := m.entries[i]
+	spent := entry.TimeSpent
+	if spent == 0 && m.timeDefault != nil {
+		spent = m.timeDefault(entry.Details)
+	}
+
+	m.cursor = i
+	m.editingTime = true
+	m.timeInput.SetValue("")
+	if spent > 0 {
+		m.timeInput.SetValue(FormatTimeSpent(spent))
+	}
+	m.timeInput.CursorEnd()
+	return m.timeInput.Focus()
+}
+
+// recordTime records the time spent on an issue worked on and saves it to the session
+func (m *reviewModel) recordTime(i int, spent time.Duration) {
+	entry := &m.entries[i]
+	entry.TimeSpent = spent
+	delete(m.rendered, i)
+	if i == m.cursor {
+		m.showCurrent()
+	}
+
+	if m.session != nil {
+		if err := m.session.RecordTimeSpent(entry.Details, spent); err != nil {
+			m.status = fmt.Sprintf("⚠️  Your answer could not be saved for resuming: %s", err)
+		}
+	}
+}
+
+// answer records the answer for an entry and saves it to the session
+func (m *reviewModel) answer(i int, decision string, notes string) {
+	entry := &m.entries[i]
+	entry.Decision = decision
+	entry.Notes = notes
+	if decision != DecisionWorked {
+		entry.TimeSpent = 0
+	}
+	delete(m.rendered, i)
+	if i == m.cursor {
+		m.showCurrent()
+	}
+
+	if m.session != nil {
+		var err error
+		if entry.Item.Kind == ItemKindLinearIssue {
+			err = m.session.Record(entry.Details, notes, decision == DecisionWorked, decision == DecisionSkipped)
+			if err == nil && entry.TimeSpent > 0 {
+				// Recording the answer again starts it over, keep the time given for it
+				err = m.session.RecordTimeSpent(entry.Details, entry.TimeSpent)
+			}
+		} else {
+			// Leaving a GitHub item out is answering "no" for it
+			err = m.session.RecordItem(entry.Item, notes, decision == DecisionNotWorked)
+		}
+		if err != nil {
+			m.status = fmt.Sprintf("⚠️  Your answer could not be saved for resuming: %s", err)
+		}
+	}
+}
+
+func (m *reviewModel) move(delta int) {
+	cursor := m.cursor + delta
+	if cursor < 0 {
+		cursor = 0
+	}
+	if cursor > len(m.entries)-1 {
+		cursor = len(m.entries) - 1
+	}
+	if cursor != m.cursor {
+		m.cursor = cursor
+		m.showCurrent()
+	}
+}
+
+// Layout of the screen: the list on the left, details on the right and two lines at the bottom
+func (m reviewModel) listWidth() int {
+	return min(44, m.width/3)
+}
+
+func (m reviewModel) detailWidth() int {
+	return max(20, m.width-m.listWidth()-3)
+}
+
+func (m reviewModel) bodyHeight() int {
+	return max(3, m.height-2)
+}
+
+func (m *reviewModel) layout() {
+	m.viewport.Width = m.detailWidth()
+	m.viewport.Height = m.bodyHeight()
+	m.notes.SetWidth(m.detailWidth())
+	m.notes.SetHeight(m.bodyHeight() - 2)
+	m.timeInput.Width = m.detailWidth() - 3
+	m.showCurrent()
+}
+
+// showCurrent puts the entry under the cursor in the detail pane
+func (m *reviewModel) showCurrent() {
+	if len(m.entries) == 0 {
+		m.viewport.SetContent("Nothing to review")
+		return
+	}
+
+	content, ok := m.rendered[m.cursor]
+	if !ok {
+		content = m.renderEntry(m.entries[m.cursor])
+		m.rendered[m.cursor] = content
+	}
+	m.viewport.SetContent(content)
+	m.viewport.GotoTop()
+}
+
+func (m reviewModel) renderEntry(entry ReviewEntry) string {
+	var b strings.Builder
+	width := m.detailWidth()
+
+	switch {
+	case entry.Item.Kind != ItemKindLinearIssue:
+		renderActivityItem(&b, entry.Item, width)
+		switch {
+		case entry.Item.Kind == ItemKindCommits:
+			fmt.Fprintln(&b, "\nCommits are always part of the summary.")
+		case entry.Decision == DecisionNotWorked:
+			fmt.Fprintln(&b, "\n🚫 Left out of the summary")
+		case entry.Notes != "":
+			fmt.Fprintln(&b, "\n✍️  Your notes:")
+			for _, line := range noteLines(entry.Notes) {
+				fmt.Fprintf(&b, "   %s\n", line)
+			}
+		}
+		return b.String()
+	case !entry.Loaded:
+		fmt.Fprintf(&b, "📋 %s\n\n⏳ Loading details...\n", entry.Item.Title)
+		return b.String()
+	case entry.Err != nil:
+		fmt.Fprintf(&b, "📋 %s\n\n⚠️  Failed to fetch details: %s\n", entry.Item.Title, entry.Err)
+		return b.String()
+	}
+
+	RenderIssueDetails(&b, entry.Details, width, m.style)
+
+	notes := entry.Notes
+	heading := "✍️  Your notes:"
+	if notes == "" && entry.Decision == DecisionPending {
+		notes = m.defaultNotes(entry)
+		heading = "📝 Your notes from earlier today:"
+	}
+	if notes != "" {
+		fmt.Fprintln(&b, heading)
+		for _, line := range noteLines(notes) {
+			fmt.Fprintf(&b, "   %s\n", line)
+		}
+	}
+	if entry.TimeSpent > 0 {
+		fmt.Fprintf(&b, "⏱️  Time spent: %s\n", FormatTimeSpent(entry.TimeSpent))
+	}
+	return b.String()
+}
+
+// renderActivityItem writes the detail view of a GitHub item
+func renderActivityItem(b *strings.Builder, item ActivityItem, width int) {
+	fmt.Fprintln(b, strings.Repeat("═", width))
+	fmt.Fprintf(b, "%s %s\n", activityIcon(item.Kind), item.Title)
+	fmt.Fprintln(b, strings.Repeat("═", width))
+	fmt.Fprintln(b)
+
+	if item.Identifier != "" {
+		fmt.Fprintf(b, "🔖 %s\n", item.Identifier)
+	}
+	if item.URL != "" {
+		fmt.Fprintf(b, "🔗 URL: %s\n", item.URL)
+	}
+	if item.Repo != "" {
+		fmt.Fprintf(b, "📁 Repository: %s\n", item.Repo)
+	}
+	if item.OccurredAt != "" {
+		fmt.Fprintf(b, "🕞 Occurred at: %s\n", item.OccurredAt)
+	}
+}
+
+func activityIcon(kind string) string {
+	switch kind {
+	case ItemKindPullRequest:
+		return "🔀"
+	case ItemKindReview:
+		return "👀"
+	case ItemKindIssue:
+		return "🐛"
+	case ItemKindCommits:
+		return "💾"
+	}
+	return "📋"
+}
+
+var (
+	selectedStyle = lipgloss.NewStyle().Reverse(true)
+	paneStyle     = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder()).BorderRight(true).PaddingRight(1)
+	helpStyle     = lipgloss.NewStyle().Faint(true)
+)
+
+func (m reviewModel) View() string {
+	list := paneStyle.Width(m.listWidth()).Height(m.bodyHeight()).Render(m.listView())
+
+	var detail string
+	if m.editingTime {
+		entry := m.entries[m.cursor]
+		detail = fmt.Sprintf("⏱️  Time spent on %s\n\n%s", entryLabel(entry), m.timeInput.View())
+	} else if m.editing {
+		entry := m.entries[m.cursor]
+		detail = fmt.Sprintf("✍️  Notes for %s\n\n%s", entryLabel(entry), m.notes.View())
+	} else {
+		detail = m.viewport.View()
+	}
+
+	help := "↑/↓ move • y yes, with notes • n no / leave out • s skip • e edit notes • pgup/pgdn scroll • q write summary"
+	if m.askTime {
+		help = "↑/↓ move • y yes, with notes • n no / leave out • s skip • e edit notes • t time spent • pgup/pgdn scroll • q write summary"
+	}
+	if m.editing {
+		help = "ctrl+s save notes • esc cancel"
+	}
+	if m.editingTime {
+		help = "enter save time spent • esc keep as it was"
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, list, " ", detail) + "\n" +
+		ansi.Truncate(m.status, m.width, "…") + "\n" +
+		helpStyle.Render(ansi.Truncate(help, m.width, "…"))
+}
+
+func (m reviewModel) listView() string {
+	height := m.bodyHeight()
+	width := m.listWidth()
+
+	// Keep the cursor in sight when the list is taller than the pane
+	start := 0
+	if m.cursor >= height {
+		start = m.cursor - height + 1
+	}
+	end := min(len(m.entries), start+height)
+
+	lines := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		line := ansi.Truncate(entryMarker(m.entries[i])+" "+entryLabel(m.entries[i]), width, "…")
+		if i == m.cursor {
+			line = selectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// entryMarker shows the state of an entry in the list
+func entryMarker(entry ReviewEntry) string {
+	if entry.Item.Kind != ItemKindLinearIssue {
+		switch entry.Decision {
+		case DecisionWorked:
+			return "✅"
+		case DecisionNotWorked:
+			return "🚫"
+		}
+		return activityIcon(entry.Item.Kind)
+	}
+
+	switch {
+	case entry.Err != nil:
+		return "⚠️"
+	case entry.Decision == DecisionWorked:
+		return "✅"
+	case entry.Decision == DecisionNotWorked:
+		return "➖"
+	case entry.Decision == DecisionSkipped:
+		return "⏭️"
+	case !entry.Loaded:
+		return "⏳"
+	}
+	return "❓"
+}
+
+// notesPlaceholder is the hint shown in the empty notes of an item of the kind
+func notesPlaceholder(kind string) string {
+	switch kind {
+	case ItemKindPullRequest:
+		return "What did this pull request change, and why did it matter?"
+	case ItemKindReview:
+		return "What did you look at or ask for in this review?"
+	case ItemKindIssue:
+		return "What is this issue about, and why did you open it?"
+	case ItemKindCommits:
+		return "What were these commits about?"
+	}
+	return "What did you do on this issue?"
+}
+
+func entryLabel(entry ReviewEntry) string {
+	if entry.Loaded && entry.Details.Identifier != "" {
+		return entry.Details.Identifier + " " + entry.Details.Title
+	}
+	if entry.Item.Identifier != "" {
+		return entry.Item.Identifier + " " + entry.Item.Title
+	}
+	return entry.Item.Title
+}
@@ -0,0 +1,210 @@
+package daily
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReviewModel(t *testing.T, session *Session) reviewModel {
+	t.Helper()
+	return newReviewModel(ReviewOptions{
+		Issues: []ActivityItem{
+			{Source: "linear", Kind: ItemKindLinearIssue, ID: "issue-1", Title: "Implement auth"},
+			{Source: "linear", Kind: ItemKindLinearIssue, ID: "issue-2", Title: "Fix login"},
+		},
+		Activity: []ActivityItem{
+			{Source: "github", Kind: ItemKindPullRequest, ID: "https://github.com/acme/api/pull/7", Identifier: "acme/api#7", Title: "Add retries", URL: "https://github.com/acme/api/pull/7"},
+			{Source: "github", Kind: ItemKindCommits, ID: "acme/api", Title: "acme/api: 3 commit(s)", Repo: "acme/api", Count: 3},
+		},
+		PreviousNotes: map[string]string{"issue-2": "Reproduced the bug"},
+		Session:       session,
+	}, glamour.WithStandardStyle(styles.NoTTYStyle))
+}
+
+func press(t *testing.T, model reviewModel, keys ...string) reviewModel {
+	t.Helper()
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "ctrl+s":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlS}
+		case "ctrl+c":
+			msg = tea.KeyMsg{Type: tea.KeyCtrlC}
+		case "esc":
+			msg = tea.KeyMsg{Type: tea.KeyEsc}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		case "up":
+			msg = tea.KeyMsg{Type: tea.KeyUp}
+		case "down":
+			msg = tea.KeyMsg{Type: tea.KeyDown}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		updated, _ := model.Update(msg)
+		model = updated.(reviewModel)
+	}
+	return model
+}
+
+func deliver(model reviewModel, fetched PrefetchedIssue) reviewModel {
+	updated, _ := model.Update(issueDetailsMsg(fetched))
+	return updated.(reviewModel)
+}
+
+// Test answers can be given in any order, changed, and are saved to the session
+func Test_ReviewModel_Answers(t *testing.T) {
+	// Arrange
+	session := NewSession(t.TempDir(), time.Now(), Window{})
+	model := newTestReviewModel(t, session)
+	model = deliver(model, PrefetchedIssue{Item: model.entries[1].Item, Details: LinearIssueDetails{ID: "issue-2", Identifier: "TEST-2", Title: "Fix login"}})
+	model = deliver(model, PrefetchedIssue{Item: model.entries[0].Item, Details: LinearIssueDetails{ID: "issue-1", Identifier: "TEST-1", Title: "Implement auth"}})
+
+	// Act - answer "no" for the first issue, write notes for the second, then go back
+	model = press(t, model, "n")
+	model = press(t, model, "y")
+	require.True(t, model.editing)
+	assert.Equal(t, "Reproduced the bug", model.notes.Value(), "earlier notes are offered")
+	model = press(t, model, " and fixed it", "ctrl+s")
+	model = press(t, model, "up", "s")
+
+	// Assert
+	assert.Equal(t, DecisionSkipped, model.entries[0].Decision)
+	assert.Equal(t, DecisionWorked, model.entries[1].Decision)
+	assert.Equal(t, "Reproduced the bug and fixed it", model.entries[1].Notes)
+	assert.Len(t, session.Answers, 2, "changing an answer replaces it")
+
+	worked, notWorked, unavailable := ReviewResults(model.entries)
+	require.Len(t, worked, 1)
+	assert.Equal(t, "TEST-2", worked[0].Details.Identifier)
+	assert.Empty(t, notWorked)
+	assert.Empty(t, unavailable)
+}
+
+// Test the time spent is asked after the notes of an issue when time is asked, and can be changed
+func Test_ReviewModel_TimeSpent(t *testing.T) {
+	// Arrange
+	session := NewSession(t.TempDir(), time.Now(), Window{})
+	model := newTestReviewModel(t, session)
+	model.askTime = true
+	model.timeDefault = func(details LinearIssueDetails) time.Duration { return 45 * time.Minute }
+	model = deliver(model, PrefetchedIssue{Item: model.entries[0].Item, Details: LinearIssueDetails{ID: "issue-1", Identifier: "TEST-1", Title: "Implement auth"}})
+
+	// Act - write notes, then keep the estimate offered
+	model = press(t, model, "y", "Wired the login form", "ctrl+s")
+	require.True(t, model.editingTime)
+	assert.Equal(t, "45m", model.timeInput.Value(), "the estimate is offered")
+	assert.Contains(t, model.View(), "Time spent on TEST-1 Implement auth")
+	model = press(t, model, "enter")
+
+	// Act - an answer without a unit is refused, then corrected
+	model = press(t, model, "t")
+	model.timeInput.SetValue("90")
+	model = press(t, model, "enter")
+	assert.True(t, model.editingTime)
+	assert.Contains(t, model.status, "has no unit")
+	model.timeInput.SetValue("1h30")
+	model = press(t, model, "enter")
+
+	// Assert
+	assert.False(t, model.editingTime)
+	assert.Equal(t, 90*time.Minute, model.entries[0].TimeSpent)
+	assert.Equal(t, 90, session.Answers[0].Issue.Minutes)
+
+	worked, _, _ := ReviewResults(model.entries)
+	require.Len(t, worked, 1)
+	assert.Equal(t, 90*time.Minute, worked[0].TimeSpent)
+}
+
+// Test issues still loading or failed, and commits, take no answer
+func Test_ReviewModel_NotAnswerable(t *testing.T) {
+	model := newTestReviewModel(t, nil)
+
+	model = press(t, model, "n")
+	assert.Equal(t, DecisionPending, model.entries[0].Decision)
+	assert.Contains(t, model.status, "still loading")
+
+	model = deliver(model, PrefetchedIssue{Item: model.entries[0].Item, Err: errors.New("timeout")})
+	model = press(t, model, "y")
+	assert.False(t, model.editing)
+
+	model = press(t, model, "down", "down", "down", "n")
+	assert.Contains(t, model.status, "Commits are always part of the summary")
+	assert.Contains(t, model.View(), "acme/api#7 Add retries")
+
+	_, _, unavailable := ReviewResults(model.entries)
+	require.Len(t, unavailable, 1)
+	assert.Equal(t, "linear (Implement auth)", unavailable[0].Source)
+}
+
+// Test leaving the notes editor with esc keeps the issue unanswered
+func Test_ReviewModel_CancelNotes(t *testing.T) {
+	model := newTestReviewModel(t, nil)
+	model = deliver(model, PrefetchedIssue{Item: model.entries[0].Item, Details: LinearIssueDetails{ID: "issue-1"}})
+
+	model = press(t, model, "e", "typed", "esc")
+
+	assert.False(t, model.editing)
+	assert.Equal(t, DecisionPending, model.entries[0].Decision)
+}
+
+// Test q finishes the review while ctrl+c marks it as interrupted
+func Test_ReviewModel_Quit(t *testing.T) {
+	model := newTestReviewModel(t, nil)
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+
+	model = press(t, model, "ctrl+c")
+	assert.True(t, model.interrupted)
+}
+
+// Test issues with a prepared answer are answered as soon as their details arrive
+func Test_ReviewModel_PreparedAnswers(t *testing.T) {
+	model := newTestReviewModel(t, nil)
+	model.answers = Answers{"TEST-1": {Worked: true, Notes: "From the answers file"}}
+
+	model = deliver(model, PrefetchedIssue{Item: model.entries[0].Item, Details: LinearIssueDetails{ID: "issue-1", Identifier: "TEST-1"}})
+
+	assert.Equal(t, DecisionWorked, model.entries[0].Decision)
+	assert.Equal(t, "From the answers file", model.entries[0].Notes)
+}
+
+// Test GitHub items can be annotated or left out, and the answers reach the session
+func Test_ReviewModel_GitHubItems(t *testing.T) {
+	// Arrange
+	session := NewSession(t.TempDir(), time.Now(), Window{})
+	model := newTestReviewModel(t, session)
+
+	// Act - add notes to the pull request, then leave it out after all
+	model = press(t, model, "down", "down", "y", "Tricky retry semantics", "ctrl+s")
+	assert.Equal(t, ItemAnswer{Notes: "Tricky retry semantics"}, session.ItemAnswers()["https://github.com/acme/api/pull/7"])
+	model = press(t, model, "n")
+
+	// Assert
+	assert.Equal(t, DecisionNotWorked, model.entries[2].Decision)
+	assert.Equal(t, ItemAnswer{Excluded: true}, session.ItemAnswers()["https://github.com/acme/api/pull/7"])
+	assert.Empty(t, session.Answers, "GitHub answers are not Linear answers")
+}
+
+// Test the notes editor of a GitHub item names the item and asks about it
+func Test_ReviewModel_EditGitHubItem(t *testing.T) {
+	// Arrange
+	model := newTestReviewModel(t, nil)
+
+	// Act
+	model = press(t, model, "down", "down", "e")
+
+	// Assert
+	require.True(t, model.editing)
+	assert.Contains(t, model.View(), "Notes for acme/api#7 Add retries")
+	assert.Equal(t, notesPlaceholder(ItemKindPullRequest), model.notes.Placeholder)
+}
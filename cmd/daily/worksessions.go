@@ -0,0 +1,173 @@
+package daily
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Defaults of the work session estimator, see "sessions.gap" and "sessions.leadIn"
+const (
+	DefaultSessionGap    = 2 * time.Hour
+	DefaultSessionLeadIn = 30 * time.Minute
+)
+
+// sessionRounding is what estimates are rounded to, being rough guesses anyway
+const sessionRounding = 15 * time.Minute
+
+// WorkSessionEstimator guesses active time from activity timestamps. Timestamps less than
+// Gap apart belong to the same work session, and each session starts LeadIn before its
+// first timestamp, for the work that led to it.
+type WorkSessionEstimator struct {
+	Gap    time.Duration
+	LeadIn time.Duration
+}
+
+// WorkSession is a stretch of continuous activity
+type WorkSession struct {
+	Start time.Time
+	End   time.Time
+}
+
+// TimeEstimate is the estimated active time for a repository or a Linear issue
+type TimeEstimate struct {
+	// Name is an "owner/repo" or a Linear identifier
+	Name  string
+	Spent time.Duration
+}
+
+// ActiveTime is the estimated active time of a day or a range
+type ActiveTime struct {
+	// Total counts overlapping work on several repositories once
+	Total  time.Duration
+	Repos  []TimeEstimate
+	Issues []TimeEstimate
+}
+
+// Empty reports whether nothing could be estimated
+func (a ActiveTime) Empty() bool {
+	return a.Total == 0 && len(a.Repos) == 0 && len(a.Issues) == 0
+}
+
+// NewWorkSessionEstimator reads the gap and lead-in from config, falling back to the defaults
+func NewWorkSessionEstimator(config *viper.Viper) WorkSessionEstimator {
+	estimator := WorkSessionEstimator{Gap: DefaultSessionGap, LeadIn: DefaultSessionLeadIn}
+	if gap := config.GetDuration("sessions.gap"); gap > 0 {
+		estimator.Gap = gap
+	}
+	if config.IsSet("sessions.leadIn") {
+		estimator.LeadIn = max(0, config.GetDuration("sessions.leadIn"))
+	}
+	return estimator
+}
+
+// Sessions clusters timestamps into work sessions, in order
+func (e WorkSessionEstimator) Sessions(times []time.Time) []WorkSession {
+	sorted := append([]time.Time{}, times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var sessions []WorkSession
+	for _, at := range sorted {
+		if n := len(sessions); n > 0 && at.Sub(sessions[n-1].End) <= e.Gap {
+			sessions[n-1].End = at
+			continue
+		}
+		sessions = append(sessions, WorkSession{Start: at.Add(-e.LeadIn), End: at})
+	}
+	return sessions
+}
+
+// Duration is the time covered by the work sessions of the timestamps, rounded to a
+// quarter of an hour
+func (e WorkSessionEstimator) Duration(times []time.Time) time.Duration {
+	var total time.Duration
+	for _, session := range e.Sessions(times) {
+		total += session.End.Sub(session.Start)
+	}
+	if total == 0 {
+		return 0
+	}
+	return max(sessionRounding, (total + sessionRounding/2).Truncate(sessionRounding))
+}
+
+// IssueTime estimates the time spent on a Linear issue from the commits and GitHub items
+// mentioning its identifier. It returns 0 when nothing mentions it.
+func (e WorkSessionEstimator) IssueTime(identifier string, activity GitHubActivity) time.Duration {
+	if identifier == "" {
+		return 0
+	}
+
+	var times []time.Time
+	for _, stamp := range activityTimestamps(activity) {
+		if strings.Contains(strings.ToUpper(stamp.text), strings.ToUpper(identifier)) {
+			times = append(times, stamp.at)
+		}
+	}
+	return e.Duration(times)
+}
+
+// Estimate estimates the active time of the GitHub activity, in total, per repository and
+// for each of the Linear issues
+func (e WorkSessionEstimator) Estimate(activity GitHubActivity, issues []IssueWithNotes) ActiveTime {
+	var estimate ActiveTime
+
+	var all []time.Time
+	byRepo := make(map[string][]time.Time)
+	for _, stamp := range activityTimestamps(activity) {
+		all = append(all, stamp.at)
+		byRepo[stamp.repo] = append(byRepo[stamp.repo], stamp.at)
+	}
+	estimate.Total = e.Duration(all)
+
+	for repo, times := range byRepo {
+		estimate.Repos = append(estimate.Repos, TimeEstimate{Name: repo, Spent: e.Duration(times)})
+	}
+	sortEstimates(estimate.Repos)
+
+	for _, issue := range issues {
+		if spent := e.IssueTime(issue.Details.Identifier, activity); spent > 0 {
+			estimate.Issues = append(estimate.Issues, TimeEstimate{Name: issue.Details.Identifier, Spent: spent})
+		}
+	}
+	return estimate
+}
+
+// sortEstimates puts the largest estimates first
+func sortEstimates(estimates []TimeEstimate) {
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].Spent != estimates[j].Spent {
+			return estimates[i].Spent > estimates[j].Spent
+		}
+		return estimates[i].Name < estimates[j].Name
+	})
+}
+
+type activityTimestamp struct {
+	at   time.Time
+	repo string
+	// text is what may mention a Linear identifier, such as a commit headline
+	text string
+}
+
+// activityTimestamps lists when each commit, pull request, review and issue happened
+func activityTimestamps(activity GitHubActivity) []activityTimestamp {
+	var stamps []activityTimestamp
+	add := func(value string, repo string, text string) {
+		if at, err := time.Parse(time.RFC3339, value); err == nil {
+			stamps = append(stamps, activityTimestamp{at: at, repo: repo, text: text})
+		}
+	}
+
+	for _, commit := range activity.Commits {
+		add(commit.CommittedAt, commit.Repo, commit.Headline)
+	}
+	for _, pr := range append(append([]GitHubPullRequest{}, activity.PullRequestsCreated...), activity.PullRequestsReviewed...) {
+		add(pr.OccurredAt, pr.RepoOwner+"/"+pr.RepoName, pr.Title)
+	}
+	for _, issue := range activity.IssuesCreated {
+		add(issue.OccurredAt, issue.RepoOwner+"/"+issue.RepoName, issue.Title)
+	}
+	return stamps
+}
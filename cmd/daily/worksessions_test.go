@@ -0,0 +1,108 @@
+package daily
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func at(clock string) time.Time {
+	t, _ := time.Parse(time.RFC3339, "2026-10-16T"+clock+":00Z")
+	return t
+}
+
+// Test timestamps less than the gap apart make up one session, with the lead-in before it
+func Test_WorkSessionEstimator_Sessions(t *testing.T) {
+	// Arrange
+	estimator := WorkSessionEstimator{Gap: time.Hour, LeadIn: 30 * time.Minute}
+
+	// Act
+	sessions := estimator.Sessions([]time.Time{at("14:00"), at("09:00"), at("09:40"), at("10:30")})
+
+	// Assert
+	assert.Equal(t, []WorkSession{
+		{Start: at("08:30"), End: at("10:30")},
+		{Start: at("13:30"), End: at("14:00")},
+	}, sessions)
+	assert.Equal(t, 2*time.Hour+30*time.Minute, estimator.Duration([]time.Time{at("14:00"), at("09:00"), at("09:40"), at("10:30")}))
+	assert.Zero(t, estimator.Duration(nil))
+}
+
+// Test the gap and lead-in are read from config
+func Test_NewWorkSessionEstimator(t *testing.T) {
+	// Arrange
+	config := viper.New()
+
+	// Act
+	defaults := NewWorkSessionEstimator(config)
+	config.Set("sessions.gap", "45m")
+	config.Set("sessions.leadIn", "0s")
+	configured := NewWorkSessionEstimator(config)
+
+	// Assert
+	assert.Equal(t, WorkSessionEstimator{Gap: DefaultSessionGap, LeadIn: DefaultSessionLeadIn}, defaults)
+	assert.Equal(t, WorkSessionEstimator{Gap: 45 * time.Minute, LeadIn: 0}, configured)
+}
+
+func sessionActivity() GitHubActivity {
+	return GitHubActivity{
+		Commits: []GitHubCommit{
+			{Repo: "acme/api", Headline: "ENG-1: Add retries", CommittedAt: "2026-10-16T10:05:00Z"},
+			{Repo: "acme/api", Headline: "eng-1 handle timeouts", CommittedAt: "2026-10-16T11:02:00Z"},
+			{Repo: "acme/web", Headline: "ENG-2: Unrelated", CommittedAt: "2026-10-16T15:00:00Z"},
+		},
+		PullRequestsCreated:  []GitHubPullRequest{{Title: "[ENG-1] Retries", RepoOwner: "acme", RepoName: "api", OccurredAt: "2026-10-16T11:20:00Z"}},
+		PullRequestsReviewed: []GitHubPullRequest{{Title: "Bump deps", RepoOwner: "acme", RepoName: "web", OccurredAt: "2026-10-16T15:40:00Z"}},
+	}
+}
+
+// Test issues are estimated from the activity mentioning their identifier
+func Test_WorkSessionEstimator_IssueTime(t *testing.T) {
+	// Arrange
+	estimator := WorkSessionEstimator{Gap: DefaultSessionGap, LeadIn: DefaultSessionLeadIn}
+
+	// Act & Assert
+	assert.Equal(t, 105*time.Minute, estimator.IssueTime("ENG-1", sessionActivity()))
+	assert.Equal(t, 30*time.Minute, estimator.IssueTime("ENG-2", sessionActivity()))
+	assert.Zero(t, estimator.IssueTime("ENG-3", sessionActivity()))
+}
+
+// Test active time is estimated in total, per repository and per issue
+func Test_WorkSessionEstimator_Estimate(t *testing.T) {
+	// Arrange
+	estimator := WorkSessionEstimator{Gap: DefaultSessionGap, LeadIn: DefaultSessionLeadIn}
+	issues := []IssueWithNotes{{Details: LinearIssueDetails{Identifier: "ENG-1"}}, {Details: LinearIssueDetails{Identifier: "ENG-9"}}}
+
+	// Act
+	active := estimator.Estimate(sessionActivity(), issues)
+
+	// Assert
+	assert.Equal(t, 105*time.Minute+75*time.Minute, active.Total)
+	assert.Equal(t, []TimeEstimate{{Name: "acme/api", Spent: 105 * time.Minute}, {Name: "acme/web", Spent: 75 * time.Minute}}, active.Repos)
+	assert.Equal(t, []TimeEstimate{{Name: "ENG-1", Spent: 105 * time.Minute}}, active.Issues)
+}
+
+// Test estimates are kept with the day, summed over a report and shown in the summary
+func Test_ActiveTime_Export(t *testing.T) {
+	// Arrange
+	data := SummaryData{ActiveTime: ActiveTime{Total: time.Hour, Repos: []TimeEstimate{{Name: "acme/api", Spent: time.Hour}}}}
+	monday := NewDailyExport(data)
+	tuesday := NewDailyExport(data)
+	var out bytes.Buffer
+
+	// Act
+	rollup := RollupDays([]DailyExport{monday, tuesday})
+	err := RenderSummary(&out, monday.SummaryData(), "")
+
+	// Assert
+	require.NotNil(t, rollup.ActiveTime)
+	assert.Equal(t, 120, rollup.ActiveTime.Total)
+	assert.Equal(t, []ExportEstimate{{Name: "acme/api", Minutes: 120}}, rollup.ActiveTime.Repos)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "## Estimated Active Time")
+	assert.Contains(t, out.String(), "- acme/api: ~1h")
+}
@@ -0,0 +1,170 @@
+package history
+
+import (
+	"cli/main/cmd/daily"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// HistoryCmd lists and shows the days recorded by earlier daily runs
+var HistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List and search the days recorded by earlier daily runs",
+	Long: `Every "daily" run keeps the day's data, with its issues, pull requests,
+notes, sources and window, in a local database under "data.dir". List the
+recorded days, narrowed down by date range, repository, Linear identifier or
+label, and show any of them again.
+
+Example:
+  crab history                          # Every recorded day
+  crab history --since 2025-10-01       # Days since the 1st of October
+  crab history --repo acme/api          # Days with activity in acme/api
+  crab history --issue ENG-123          # Days you worked on ENG-123, with your notes
+  crab history --label bug --until yesterday
+  crab history show 2025-10-22          # The summary of a day
+  crab history show yesterday -f json   # A day's data as JSON`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := parseFilter(cmd)
+		if err != nil {
+			return err
+		}
+
+		history, err := openHistory()
+		if err != nil {
+			return err
+		}
+		defer history.Close()
+
+		days, err := history.Days(filter)
+		if err != nil {
+			return err
+		}
+
+		if len(days) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No recorded days match")
+			return nil
+		}
+		for _, day := range days {
+			printDay(cmd.OutOrStdout(), day, filter)
+		}
+		return nil
+	},
+}
+
+var showCmd = &cobra.Command{
+	Use:          "show <day>",
+	Short:        "Show the summary of a recorded day",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date, err := daily.ParseDay(args[0], time.Now())
+		if err != nil {
+			return err
+		}
+
+		formatFlag, _ := cmd.Flags().GetString("format")
+		format, err := daily.ParseFormat(formatFlag)
+		if err != nil {
+			return err
+		}
+
+		history, err := openHistory()
+		if err != nil {
+			return err
+		}
+		defer history.Close()
+
+		day, err := history.Get(date)
+		if err != nil {
+			return err
+		}
+		if day == nil {
+			return fmt.Errorf("nothing was recorded on %s", daily.DayKey(date))
+		}
+
+		config := viper.GetViper()
+		templatePath := config.GetString("summary.template")
+		if format == daily.FormatStandup {
+			templatePath = config.GetString("standup.template")
+		}
+		return daily.WriteFormatted(cmd.OutOrStdout(), day.SummaryData(), format, templatePath)
+	},
+}
+
+func openHistory() (*daily.History, error) {
+	dataDir, err := daily.DataDir(viper.GetViper())
+	if err != nil {
+		return nil, err
+	}
+	return daily.OpenHistory(dataDir)
+}
+
+// parseFilter reads the filter flags
+func parseFilter(cmd *cobra.Command) (daily.HistoryFilter, error) {
+	var filter daily.HistoryFilter
+	now := time.Now()
+
+	if since, _ := cmd.Flags().GetString("since"); since != "" {
+		day, err := daily.ParseDay(since, now)
+		if err != nil {
+			return filter, err
+		}
+		filter.Since = day
+	}
+	if until, _ := cmd.Flags().GetString("until"); until != "" {
+		day, err := daily.ParseDay(until, now)
+		if err != nil {
+			return filter, err
+		}
+		filter.Until = day
+	}
+
+	filter.Repo, _ = cmd.Flags().GetString("repo")
+	filter.Identifier, _ = cmd.Flags().GetString("issue")
+	filter.Label, _ = cmd.Flags().GetString("label")
+	return filter, nil
+}
+
+// printDay prints a one-line overview of a day, followed by the issues the filter is about
+func printDay(w io.Writer, day daily.DailyExport, filter daily.HistoryFilter) {
+	commits := 0
+	for _, repo := range day.GitHub.Commits {
+		commits += repo.Commits
+	}
+	fmt.Fprintf(w, "%s  %d issue(s), %d PR(s), %d review(s), %d commit(s)\n",
+		day.GeneratedAt.Format("2006-01-02 Mon"), len(day.Issues), len(day.GitHub.PullRequests), len(day.GitHub.Reviews), commits)
+
+	if filter.Identifier == "" && filter.Label == "" {
+		return
+	}
+	issueFilter := daily.HistoryFilter{Identifier: filter.Identifier, Label: filter.Label}
+	for _, issue := range day.Issues {
+		if !issueFilter.Matches(daily.DailyExport{Issues: []daily.ExportIssue{issue}}) {
+			continue
+		}
+		fmt.Fprintf(w, "    %s %s\n", issue.Identifier, issue.Title)
+		for _, line := range strings.Split(strings.TrimSpace(issue.Notes), "\n") {
+			if strings.TrimSpace(line) != "" {
+				fmt.Fprintf(w, "      - %s\n", strings.TrimSpace(line))
+			}
+		}
+	}
+}
+
+func init() {
+	HistoryCmd.Flags().String("since", "", "First day to list: YYYY-MM-DD, today or yesterday")
+	HistoryCmd.Flags().String("until", "", "Last day to list: YYYY-MM-DD, today or yesterday")
+	HistoryCmd.Flags().String("repo", "", "Only days with activity in this owner/repo")
+	HistoryCmd.Flags().String("issue", "", "Only days you worked on this Linear issue (e.g. ENG-123)")
+	HistoryCmd.Flags().String("label", "", "Only days you worked on a Linear issue with this label")
+
+	showCmd.Flags().StringP("format", "f", daily.FormatMarkdown, "Output format: markdown, json, yaml or standup")
+	HistoryCmd.AddCommand(showCmd)
+}
@@ -0,0 +1,85 @@
+package note
+
+import (
+	"cli/main/cmd/daily"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// NoteCmd captures a note about work done during the day
+var NoteCmd = &cobra.Command{
+	Use:   "note [IDENTIFIER] <text>",
+	Short: "Capture a note about your work for the next daily summary",
+	Long: `Capture a timestamped note about what you are working on, so it is not
+forgotten by the time you run "daily".
+
+When the first argument is a Linear identifier (e.g. ENG-123), the note is
+offered as your notes for that issue. Other notes are listed in the summary as
+other work. Notes are kept in the journal under "data.dir".
+
+Example:
+  crab note ENG-123 "fixed flaky test"
+  crab note "pairing with Sam on the billing migration"
+  crab note --list                # Show today's notes`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := viper.GetViper()
+
+		dataDir, err := daily.DataDir(config)
+		if err != nil {
+			return err
+		}
+
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			return listNotes(cmd, dataDir)
+		}
+
+		identifier, text, err := daily.ParseNoteArgs(args)
+		if err != nil {
+			return err
+		}
+
+		entry := daily.JournalEntry{At: time.Now(), Identifier: identifier, Text: text}
+		if err := daily.AppendJournalEntry(dataDir, entry); err != nil {
+			return err
+		}
+
+		if identifier != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "📝 Noted for %s at %s\n", identifier, entry.At.Format("15:04"))
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "📝 Noted at %s\n", entry.At.Format("15:04"))
+		}
+		return nil
+	},
+}
+
+// listNotes prints the notes captured today
+func listNotes(cmd *cobra.Command, dataDir string) error {
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	journal, err := daily.LoadJournal(dataDir, daily.Window{Since: startOfDay, Until: now})
+	if err != nil {
+		return err
+	}
+
+	if len(journal) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No notes captured today")
+		return nil
+	}
+	for _, entry := range journal {
+		if entry.Identifier != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s: %s\n", entry.At.Format("15:04"), entry.Identifier, entry.Text)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s  %s\n", entry.At.Format("15:04"), entry.Text)
+		}
+	}
+	return nil
+}
+
+func init() {
+	NoteCmd.Flags().BoolP("list", "l", false, "List the notes captured today")
+}
@@ -0,0 +1,182 @@
+package report
+
+import (
+	"cli/main/cmd/daily"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// WeeklyCmd rolls the days of the current week up into one summary
+var WeeklyCmd = &cobra.Command{
+	Use:   "weekly",
+	Short: "Summarise the week from your daily runs",
+	Long: `Roll the days recorded by "daily" since Monday up into one summary: notes are
+merged per Linear issue, commits are summed per repository and merged pull
+requests are listed on their own. Use --last for the previous week.
+
+With --fetch, the whole week is also fetched from every source, bringing in the
+latest state of issues and pull requests and activity from days without a daily
+run. Notes still come from the daily runs. Without --fetch, pull requests are
+listed as merged or open as the last daily run saw them, so one merged since
+still shows as open.
+
+The report is rendered with a built-in template, or the one at "report.template",
+and supports the same --format, --output and --on-exists options as "daily".
+
+Example:
+  crab weekly                  # Monday to today
+  crab weekly --last           # The whole previous week
+  crab weekly --fetch -o -     # Fetch the week again and print the report`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		from := daily.WeekStart(now)
+		to := now
+		if last, _ := cmd.Flags().GetBool("last"); last {
+			from = from.AddDate(0, 0, -7)
+			to = from.AddDate(0, 0, 6)
+		}
+		return runReport(cmd, from, to)
+	},
+}
+
+// ReportCmd rolls any range of days up into one summary
+var ReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarise a range of days from your daily runs",
+	Long: `Roll the days recorded by "daily" between --from and --to up into one summary,
+the same way as "weekly".
+
+Example:
+  crab report --from 2025-10-01 --to 2025-10-31
+  crab report --from 2025-10-20 --fetch --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+
+		fromFlag, _ := cmd.Flags().GetString("from")
+		from, err := daily.ParseDay(fromFlag, now)
+		if err != nil {
+			return err
+		}
+
+		to := now
+		if toFlag, _ := cmd.Flags().GetString("to"); toFlag != "" {
+			to, err = daily.ParseDay(toFlag, now)
+			if err != nil {
+				return err
+			}
+		}
+
+		if to.Before(from) {
+			return fmt.Errorf("--to %s is before --from %s", daily.DayKey(to), daily.DayKey(from))
+		}
+		return runReport(cmd, from, to)
+	},
+}
+
+func runReport(cmd *cobra.Command, from time.Time, to time.Time) error {
+	config := viper.GetViper()
+	console := cmd.ErrOrStderr()
+
+	formatFlag, _ := cmd.Flags().GetString("format")
+	format, err := daily.ParseFormat(formatFlag)
+	if err != nil {
+		return err
+	}
+	if format == daily.FormatStandup {
+		return errors.New("the standup format is only available for daily")
+	}
+
+	dataDir, err := daily.DataDir(config)
+	if err != nil {
+		return err
+	}
+
+	window := daily.ReportWindow(from, to, time.Now())
+	days, err := daily.StoredDays(dataDir, from, to)
+	if err != nil {
+		return err
+	}
+
+	var fresh *daily.SummaryData
+	if fetch, _ := cmd.Flags().GetBool("fetch"); fetch {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		fmt.Fprintf(console, "🔍 Fetching activity from %s to %s\n", daily.DayKey(from), daily.DayKey(to))
+		data := daily.FetchRange(ctx, daily.NewHTTPClient(config), window, config)
+		fresh = &data
+	} else if len(days) == 0 {
+		return fmt.Errorf("nothing was recorded between %s and %s, run with --fetch to fetch the activity instead", daily.DayKey(from), daily.DayKey(to))
+	}
+	fmt.Fprintf(console, "📚 %d recorded day(s) between %s and %s\n", len(days), daily.DayKey(from), daily.DayKey(to))
+
+	data := daily.ReportData(days, fresh, window)
+
+	output, _ := cmd.Flags().GetString("output")
+	path, err := daily.ResolveReportPath(output, config.GetString("output.dir"), from, to, format)
+	if err != nil {
+		return err
+	}
+
+	onExistsFlag, _ := cmd.Flags().GetString("on-exists")
+	if !cmd.Flags().Changed("on-exists") && config.IsSet("output.onExists") {
+		onExistsFlag = config.GetString("output.onExists")
+	}
+	onExists, err := daily.ParseExistingAction(onExistsFlag)
+	if err != nil {
+		return err
+	}
+	if _, statErr := os.Stat(path); path != daily.StdoutPath && statErr == nil && onExists == daily.ExistingAsk {
+		if info, err := os.Stdin.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+			return fmt.Errorf("%s already exists, use --on-exists to overwrite, append or merge", path)
+		}
+		onExists, err = daily.PromptExistingAction(os.Stdin, path)
+		if err != nil {
+			return err
+		}
+		if onExists == daily.ExistingCancel {
+			fmt.Fprintln(console, "🚫 Cancelled, the existing report was left untouched")
+			return nil
+		}
+	}
+
+	if err := daily.WriteSummaryOutput(data, format, config.GetString("report.template"), path, onExists); err != nil {
+		return err
+	}
+	if path != daily.StdoutPath {
+		fmt.Fprintf(console, "📂 File: %s\n", path)
+	}
+
+	if len(data.Unavailable) > 0 && fresh != nil {
+		return &daily.PartialRunError{Unavailable: data.Unavailable}
+	}
+	return nil
+}
+
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("format", "f", daily.FormatMarkdown, "Output format: markdown, json or yaml")
+	cmd.Flags().StringP("output", "o", "", "Report file or directory, or - for stdout (default: output.dir or the current directory)")
+	cmd.Flags().String("on-exists", daily.ExistingAsk, "When the report file exists: ask, overwrite, append, merge or cancel")
+	cmd.Flags().Bool("fetch", false, "Also fetch the whole range from every source for the latest activity")
+}
+
+func init() {
+	addOutputFlags(WeeklyCmd)
+	WeeklyCmd.Flags().Bool("last", false, "Summarise the previous week instead of the current one")
+
+	addOutputFlags(ReportCmd)
+	ReportCmd.Flags().String("from", "", "First day of the report: YYYY-MM-DD, today or yesterday")
+	ReportCmd.Flags().String("to", "", "Last day of the report (default: today)")
+	ReportCmd.MarkFlagRequired("from")
+}
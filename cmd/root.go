@@ -4,7 +4,15 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"cli/main/cmd/brag"
 	"cli/main/cmd/daily"
+	"cli/main/cmd/history"
+	"cli/main/cmd/note"
+	"cli/main/cmd/report"
+	"cli/main/cmd/search"
+	"cli/main/cmd/stats"
+	"cli/main/cmd/timesheet"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -35,6 +43,11 @@ to quickly create a Cobra application.`,
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
+		// Commands can report a more specific status, e.g. a partial daily run
+		var coded interface{ ExitCode() int }
+		if errors.As(err, &coded) {
+			os.Exit(coded.ExitCode())
+		}
 		os.Exit(1)
 	}
 }
@@ -47,6 +60,10 @@ func init() {
 	// will be global for your application.
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.crab.yaml)")
+	rootCmd.PersistentFlags().String("record", "", "Save every Linear and GitHub request and response to this directory, with API keys redacted")
+	rootCmd.PersistentFlags().String("replay", "", "Answer Linear and GitHub requests from a directory saved with --record, offline and without API keys, keeping data and summaries in a temporary directory")
+	viper.BindPFlag("http.record", rootCmd.PersistentFlags().Lookup("record"))
+	viper.BindPFlag("http.replay", rootCmd.PersistentFlags().Lookup("replay"))
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -54,6 +71,14 @@ func init() {
 
 	// Add child commands
 	rootCmd.AddCommand(daily.DailyCmd)
+	rootCmd.AddCommand(note.NoteCmd)
+	rootCmd.AddCommand(history.HistoryCmd)
+	rootCmd.AddCommand(report.WeeklyCmd)
+	rootCmd.AddCommand(report.ReportCmd)
+	rootCmd.AddCommand(brag.BragCmd)
+	rootCmd.AddCommand(search.SearchCmd)
+	rootCmd.AddCommand(timesheet.TimesheetCmd)
+	rootCmd.AddCommand(stats.StatsCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -83,4 +108,6 @@ func initConfig() {
 	if err := viper.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
 	}
+
+	cobra.CheckErr(daily.ConfigureReplay(viper.GetViper()))
 }
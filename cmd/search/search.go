@@ -0,0 +1,117 @@
+package search
+
+import (
+	"cli/main/cmd/daily"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// SearchCmd searches the days recorded by earlier daily runs
+var SearchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search your notes and past activity",
+	Long: `Search every day recorded by "daily": your notes and entries, Linear issue
+titles and descriptions, pull request and issue titles and commit headlines.
+Every word of the query must match, and words match their plural and -ing or
+-ed forms. Hits are ranked by relevance, the most recent first when they rank
+the same, and show the last day they were seen on.
+
+Narrow the search down with --since and --until, and with --source to notes,
+linear or github.
+
+Example:
+  crab search payment webhook retries
+  crab search "rate limit" --since 2026-01-01
+  crab search migration --source notes --source linear
+  crab search flaky test --limit 5`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		var filter daily.SearchFilter
+
+		if since, _ := cmd.Flags().GetString("since"); since != "" {
+			day, err := daily.ParseDay(since, now)
+			if err != nil {
+				return err
+			}
+			filter.Since = day
+		}
+		if until, _ := cmd.Flags().GetString("until"); until != "" {
+			day, err := daily.ParseDay(until, now)
+			if err != nil {
+				return err
+			}
+			filter.Until = day
+		}
+
+		sources, _ := cmd.Flags().GetStringSlice("source")
+		sources, err := daily.ParseSearchSources(sources)
+		if err != nil {
+			return err
+		}
+		filter.Sources = sources
+
+		dataDir, err := daily.DataDir(viper.GetViper())
+		if err != nil {
+			return err
+		}
+		history, err := daily.OpenHistory(dataDir)
+		if err != nil {
+			return err
+		}
+		defer history.Close()
+
+		days, err := history.Days(daily.HistoryFilter{Since: filter.Since, Until: filter.Until})
+		if err != nil {
+			return err
+		}
+
+		query := strings.Join(args, " ")
+		hits := daily.NewSearchIndex(days).Search(query, filter)
+		if len(hits) == 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "Nothing matches %q in %d recorded day(s)\n", query, len(days))
+			return nil
+		}
+
+		limit, _ := cmd.Flags().GetInt("limit")
+		if limit > 0 && len(hits) > limit {
+			hits = hits[:limit]
+		}
+		for _, hit := range hits {
+			printHit(cmd, hit)
+		}
+		return nil
+	},
+}
+
+// printHit prints the date, source and title of a hit, then the matching text and link
+func printHit(cmd *cobra.Command, hit daily.SearchHit) {
+	w := cmd.OutOrStdout()
+
+	title := hit.Title
+	if title == "" {
+		title = hit.Kind
+	}
+	fmt.Fprintf(w, "%s  %-6s  %s (%s)\n", hit.Date.Format("2006-01-02 Mon"), hit.Source, title, hit.Kind)
+	if hit.Snippet != title && !strings.HasSuffix(title, hit.Snippet) {
+		fmt.Fprintf(w, "    %s\n", hit.Snippet)
+	}
+	if hit.URL != "" {
+		fmt.Fprintf(w, "    %s\n", hit.URL)
+	}
+	if hit.Days > 1 {
+		fmt.Fprintf(w, "    seen on %d days\n", hit.Days)
+	}
+}
+
+func init() {
+	SearchCmd.Flags().String("since", "", "First day to search: YYYY-MM-DD, today or yesterday")
+	SearchCmd.Flags().String("until", "", "Last day to search: YYYY-MM-DD, today or yesterday")
+	SearchCmd.Flags().StringSlice("source", nil, "Only search these sources: notes, linear or github (repeatable)")
+	SearchCmd.Flags().Int("limit", 20, "Most hits to show, 0 for all")
+}
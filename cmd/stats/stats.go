@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"cli/main/cmd/daily"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// StatsCmd charts the activity recorded by daily runs and fetched from GitHub
+var StatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Chart when you work, and your commits, reviews and pull requests",
+	Long: `Chart your activity between --since and --until in the terminal, from the
+days recorded by "daily" and your GitHub contributions:
+
+  - a heatmap of commits, pull requests, reviews and issues by day of week and
+    hour, in the "timezone" config key's time zone (local time by default)
+  - commits and reviews per week
+  - pull requests opened, reviews and the number of reviews per pull request
+  - the median time from opening a pull request to merging it
+
+Use it to spot late nights, busy weekends and review imbalance. Every item counts
+once, in the week it happened, even when several runs saw it. Without a GitHub API
+key, the figures come from the recorded days only: commits are those pushed to
+default branches, and merge times are unknown.
+
+Example:
+  crab stats                       # The last 12 weeks
+  crab stats --since 2026-07-01
+  crab stats --since 2026-01-01 --until 2026-06-30`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config := viper.GetViper()
+		console := cmd.ErrOrStderr()
+		now := time.Now()
+
+		loc, err := daily.TimeZone(config)
+		if err != nil {
+			return err
+		}
+
+		since := daily.WeekStart(now).AddDate(0, 0, -7*(daily.DefaultStatsWeeks-1))
+		if sinceFlag, _ := cmd.Flags().GetString("since"); sinceFlag != "" {
+			since, err = daily.ParseDay(sinceFlag, now)
+			if err != nil {
+				return err
+			}
+		}
+		until := now
+		if untilFlag, _ := cmd.Flags().GetString("until"); untilFlag != "" {
+			until, err = daily.ParseDay(untilFlag, now)
+			if err != nil {
+				return err
+			}
+		}
+		if until.Before(since) {
+			return fmt.Errorf("--until %s is before --since %s", daily.DayKey(until), daily.DayKey(since))
+		}
+
+		dataDir, err := daily.DataDir(config)
+		if err != nil {
+			return err
+		}
+		days, err := daily.StoredDays(dataDir, since, until)
+		if err != nil {
+			return err
+		}
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// GitHub is optional, the recorded days are charted without it
+		var contributions *daily.GitHubContributions
+		var unavailable []daily.SourceStatus
+		enabled, _ := daily.EnabledSources(config)
+		for _, source := range enabled {
+			if source.Name() != "github" {
+				continue
+			}
+			window := daily.ReportWindow(since, until, now)
+			fmt.Fprintf(console, "🔍 Fetching GitHub contributions from %s to %s\n", daily.DayKey(since), daily.DayKey(until))
+			fetched, err := daily.GetViewerContributions(ctx, daily.NewHTTPClient(config), window.Since, window.Until, config)
+			if err != nil {
+				fmt.Fprintf(console, "⚠️  Failed to fetch GitHub contributions: %s\n", err)
+				unavailable = append(unavailable, daily.SourceStatus{Source: "github", Reason: err.Error()})
+				continue
+			}
+			contributions = &fetched
+		}
+
+		stats := daily.BuildStats(since, until, days, contributions, loc)
+		daily.RenderStats(cmd.OutOrStdout(), stats, loc)
+
+		if len(unavailable) > 0 {
+			return &daily.PartialRunError{Unavailable: unavailable}
+		}
+		return nil
+	},
+}
+
+func init() {
+	StatsCmd.Flags().String("since", "", "First day to chart: YYYY-MM-DD, today or yesterday (default: 12 weeks ago)")
+	StatsCmd.Flags().String("until", "", "Last day to chart (default: today)")
+}
@@ -0,0 +1,102 @@
+package timesheet
+
+import (
+	"cli/main/cmd/daily"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// TimesheetCmd exports the time recorded per issue by daily runs
+var TimesheetCmd = &cobra.Command{
+	Use:   "timesheet",
+	Short: "Export the time spent per day, issue and project",
+	Long: `Add up the time spent on Linear issues, as recorded by "daily --time", between
+--from and --to, and write it as CSV or a markdown table.
+
+By default there is a row per day and issue. Use --by issue for a row per issue
+over the whole range, or --by project for a row per Linear project.
+
+Example:
+  crab timesheet --from 2026-10-01 --to 2026-10-31 > october.csv
+  crab timesheet --from 2026-10-01 --by project
+  crab timesheet --from 2026-10-12 --format markdown -o timesheet.md`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+
+		fromFlag, _ := cmd.Flags().GetString("from")
+		from, err := daily.ParseDay(fromFlag, now)
+		if err != nil {
+			return err
+		}
+		to := now
+		if toFlag, _ := cmd.Flags().GetString("to"); toFlag != "" {
+			to, err = daily.ParseDay(toFlag, now)
+			if err != nil {
+				return err
+			}
+		}
+		if to.Before(from) {
+			return fmt.Errorf("--to %s is before --from %s", daily.DayKey(to), daily.DayKey(from))
+		}
+
+		formatFlag, _ := cmd.Flags().GetString("format")
+		format, err := daily.ParseTimesheetFormat(formatFlag)
+		if err != nil {
+			return err
+		}
+		byFlag, _ := cmd.Flags().GetString("by")
+		by, err := daily.ParseTimesheetGrouping(byFlag)
+		if err != nil {
+			return err
+		}
+
+		dataDir, err := daily.DataDir(viper.GetViper())
+		if err != nil {
+			return err
+		}
+		days, err := daily.StoredDays(dataDir, from, to)
+		if err != nil {
+			return err
+		}
+
+		rows := daily.BuildTimesheet(days, by)
+		if len(rows) == 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "⚠️  No time was recorded between %s and %s, use \"daily --time\" to record it\n", daily.DayKey(from), daily.DayKey(to))
+		}
+
+		var out io.Writer = cmd.OutOrStdout()
+		output, _ := cmd.Flags().GetString("output")
+		if output != "" && output != daily.StdoutPath {
+			file, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create timesheet: %w", err)
+			}
+			defer file.Close()
+			out = file
+		}
+
+		if err := daily.WriteTimesheet(out, rows, by, format); err != nil {
+			return err
+		}
+		if output != "" && output != daily.StdoutPath {
+			fmt.Fprintf(cmd.ErrOrStderr(), "📂 File: %s\n", output)
+		}
+		return nil
+	},
+}
+
+func init() {
+	TimesheetCmd.Flags().String("from", "", "First day of the timesheet: YYYY-MM-DD, today or yesterday")
+	TimesheetCmd.Flags().String("to", "", "Last day of the timesheet (default: today)")
+	TimesheetCmd.Flags().StringP("format", "f", daily.TimesheetCSV, "Output format: csv or markdown")
+	TimesheetCmd.Flags().String("by", daily.TimesheetByDay, "One row per day (and issue), issue or project")
+	TimesheetCmd.Flags().StringP("output", "o", "", "Timesheet file (default: stdout)")
+	TimesheetCmd.MarkFlagRequired("from")
+}